@@ -0,0 +1,237 @@
+package gotenberg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	errArchiveLimit    = errors.New("archive exceeds limits")
+	errUnsafeEntryName = errors.New("unsafe archive entry name")
+	errStopIteration   = errors.New("iteration stopped")
+)
+
+// defaultResultFilename is used when Gotenberg does not send a Content-Disposition filename.
+const defaultResultFilename = "result"
+
+// ArchiveLimits protects against oversized ZIP results when extracting them. Zero values mean no limit.
+type ArchiveLimits struct {
+	// MaxArchiveSize is the maximum size of the ZIP archive itself, which is held in memory while extracted.
+	MaxArchiveSize int64
+	// MaxEntrySize is the maximum uncompressed size of a single entry.
+	MaxEntrySize int64
+	// MaxTotalSize is the maximum uncompressed size of all entries.
+	MaxTotalSize int64
+	// MaxEntries is the maximum number of entries.
+	MaxEntries int
+}
+
+// DefaultArchiveLimits returns the limits used unless WithArchiveLimits is set: a 512 MB archive,
+// with at most 1000 entries of 512 MB each and 2 GB in total.
+func DefaultArchiveLimits() ArchiveLimits {
+	return ArchiveLimits{
+		MaxArchiveSize: 512 << 20,
+		MaxEntrySize:   512 << 20,
+		MaxTotalSize:   2 << 30,
+		MaxEntries:     1000,
+	}
+}
+
+// WithArchiveLimits sets the limits applied when extracting ZIP results with Result.Files or Client.StoreAll.
+func WithArchiveLimits(limits ArchiveLimits) ClientOption {
+	return func(c *Client) error {
+		c.archiveLimits = limits
+
+		return nil
+	}
+}
+
+// Files returns an iterator over the files of the result, without touching the disk. If the result is a ZIP archive,
+// e.g., the output of a split, each entry is yielded with its name inside the archive; otherwise, the result itself
+// is yielded with its filename. The readers are only valid until the next iteration.
+//
+// The result is closed once the iteration ends. Err must be checked after the iteration.
+func (r *Result) Files() iter.Seq2[string, io.Reader] {
+	return func(yield func(string, io.Reader) bool) {
+		defer func() {
+			_ = r.Close()
+		}()
+
+		if !r.IsArchive() {
+			name := r.Filename
+			if name == "" {
+				name = defaultResultFilename
+			}
+
+			yield(name, r.body)
+
+			return
+		}
+
+		r.err = r.iterateArchive(yield)
+	}
+}
+
+// Err returns the first error encountered while iterating over the files of the result.
+func (r *Result) Err() error {
+	return r.err
+}
+
+func (r *Result) iterateArchive(yield func(string, io.Reader) bool) error {
+	limits := r.archiveLimits
+
+	data, err := readAllLimited(r.body, limits.MaxArchiveSize)
+	if err != nil {
+		return err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("opening archive: %w", err)
+	}
+
+	if limits.MaxEntries > 0 && len(zr.File) > limits.MaxEntries {
+		return fmt.Errorf("%w: %d entries", errArchiveLimit, len(zr.File))
+	}
+
+	total := &limitCounter{limit: limits.MaxTotalSize}
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		name, err := safeEntryName(file.Name)
+		if err != nil {
+			return err
+		}
+
+		if err = yieldEntry(file, name, limits.MaxEntrySize, total, yield); err != nil {
+			if errors.Is(err, errStopIteration) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func yieldEntry(file *zip.File, name string, maxSize int64, total *limitCounter, yield func(string, io.Reader) bool) error {
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("opening archive entry %s: %w", name, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	entry := &limitedReader{r: rc, name: name, entry: &limitCounter{limit: maxSize}, total: total}
+	if !yield(name, entry) {
+		return errStopIteration
+	}
+
+	return entry.err
+}
+
+// safeEntryName validates an archive entry name and returns it cleaned, so that it can safely be joined
+// to a destination directory (zip-slip protection).
+func safeEntryName(name string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") ||
+		path.IsAbs(cleaned) || filepath.IsAbs(cleaned) || filepath.VolumeName(cleaned) != "" {
+		return "", fmt.Errorf("%w: %q", errUnsafeEntryName, name)
+	}
+
+	return cleaned, nil
+}
+
+// StoreAll stores the result of the request into the given directory. If the result is a ZIP archive,
+// e.g., the output of SplitPagesRequest or of a LibreOfficeRequest with several documents and no merge,
+// its entries are extracted; otherwise, the result is stored under its filename. It returns the paths
+// of the created files. Nothing is stored if the result cannot be read entirely.
+func (c *Client) StoreAll(ctx context.Context, req MultipartRequest, dir string, opts ...StoreOption) ([]string, error) {
+	names, err := c.StoreAllIn(ctx, req, NewDirSink(dir, opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	return paths, nil
+}
+
+func readAllLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+
+		return data, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: archive larger than %d bytes", errArchiveLimit, limit)
+	}
+
+	return data, nil
+}
+
+type limitCounter struct {
+	limit int64
+	n     int64
+}
+
+func (lc *limitCounter) add(n int) bool {
+	lc.n += int64(n)
+
+	return lc.limit <= 0 || lc.n <= lc.limit
+}
+
+// limitedReader reads an archive entry and fails once the entry or total limit is exceeded.
+type limitedReader struct {
+	r     io.Reader
+	name  string
+	entry *limitCounter
+	total *limitCounter
+	err   error
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.err != nil {
+		return 0, lr.err
+	}
+
+	n, err := lr.r.Read(p)
+
+	if !lr.entry.add(n) {
+		lr.err = fmt.Errorf("%w: entry %s larger than %d bytes", errArchiveLimit, lr.name, lr.entry.limit)
+	} else if !lr.total.add(n) {
+		lr.err = fmt.Errorf("%w: entries larger than %d bytes in total", errArchiveLimit, lr.total.limit)
+	}
+
+	if lr.err != nil {
+		return 0, lr.err
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
@@ -0,0 +1,168 @@
+package gotenberg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func archiveServer(t *testing.T, archive []byte) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentTypeZip)
+		w.Header().Set("Content-Disposition", `attachment; filename="foo.zip"`)
+		_, _ = w.Write(archive)
+	}))
+}
+
+func TestStoreAll(t *testing.T) {
+	srv := archiveServer(t, zipArchive(t, map[string]string{
+		"gotenberg_1-2.pdf": "%PDF-1",
+		"gotenberg_3-4.pdf": "%PDF-2",
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+	req := NewSplitIntervalsRequest(pdf)
+	req.SplitSpan(2)
+
+	dir := t.TempDir()
+	paths, err := c.StoreAll(context.Background(), req, dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "gotenberg_1-2.pdf"),
+		filepath.Join(dir, "gotenberg_3-4.pdf"),
+	}, paths)
+
+	data, err := os.ReadFile(filepath.Join(dir, "gotenberg_3-4.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-2", string(data))
+}
+
+func TestStoreAllSingleFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="foo.pdf"`)
+		_, _ = w.Write([]byte("%PDF-"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	paths, err := c.StoreAll(context.Background(), NewFlattenRequest(pdf), dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "foo.pdf")}, paths)
+}
+
+func TestResultFilesZipSlip(t *testing.T) {
+	srv := archiveServer(t, zipArchive(t, map[string]string{"../../evil.pdf": "%PDF-"}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, err = c.StoreAll(context.Background(), NewSplitPagesRequest(pdf), filepath.Join(dir, "out"))
+	require.ErrorIs(t, err, errUnsafeEntryName)
+	assert.NoFileExists(t, filepath.Join(dir, "evil.pdf"))
+}
+
+func TestResultFilesLimits(t *testing.T) {
+	srv := archiveServer(t, zipArchive(t, map[string]string{"a.pdf": "0123456789", "b.pdf": "0123456789"}))
+	defer srv.Close()
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	c, err := NewClient(srv.URL, nil, WithArchiveLimits(ArchiveLimits{MaxEntrySize: 5}))
+	require.NoError(t, err)
+
+	res, err := c.Do(context.Background(), NewSplitPagesRequest(pdf))
+	require.NoError(t, err)
+
+	for _, r := range res.Files() {
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, errArchiveLimit)
+	}
+	require.ErrorIs(t, res.Err(), errArchiveLimit)
+
+	c, err = NewClient(srv.URL, nil, WithArchiveLimits(ArchiveLimits{MaxEntries: 1}))
+	require.NoError(t, err)
+
+	res, err = c.Do(context.Background(), NewSplitPagesRequest(pdf))
+	require.NoError(t, err)
+
+	for range res.Files() {
+		t.Fatal("no entry expected")
+	}
+	require.ErrorIs(t, res.Err(), errArchiveLimit)
+
+	// Files are yielded in memory.
+	c, err = NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	res, err = c.Do(context.Background(), NewSplitPagesRequest(pdf))
+	require.NoError(t, err)
+
+	contents := make(map[string]string)
+	for name, r := range res.Files() {
+		data, readErr := io.ReadAll(r)
+		require.NoError(t, readErr)
+		contents[name] = string(data)
+	}
+	require.NoError(t, res.Err())
+	assert.Equal(t, map[string]string{"a.pdf": "0123456789", "b.pdf": "0123456789"}, contents)
+}
+
+func TestSafeEntryName(t *testing.T) {
+	for _, name := range []string{"a.pdf", "dir/a.pdf", "./a.pdf"} {
+		_, err := safeEntryName(name)
+		require.NoError(t, err, name)
+	}
+
+	for _, name := range []string{"../a.pdf", "/etc/passwd", `..\a.pdf`, "dir/../../a.pdf", ".", ""} {
+		_, err := safeEntryName(name)
+		require.ErrorIs(t, err, errUnsafeEntryName, name)
+	}
+}
@@ -13,11 +13,13 @@ type Request interface {
 	formFields() map[formField]string
 	formDocuments() map[string]document.Document
 	formEmbeds() map[string]document.Document
+	progressFunc() ProgressFunc
 }
 
 type baseRequest struct {
-	headers map[httpHeader]string
-	fields  map[formField]string
+	headers  map[httpHeader]string
+	fields   map[formField]string
+	progress ProgressFunc
 }
 
 func newBaseRequest() *baseRequest {
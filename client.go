@@ -5,10 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"os"
-	"path/filepath"
-	"runtime"
+	"net/url"
+	"time"
 )
 
 var (
@@ -27,12 +27,43 @@ type MultipartRequest interface {
 
 // Client facilitates interacting with the Gotenberg API.
 type Client struct {
-	hostname   string
-	httpClient *http.Client
+	baseURL     *url.URL
+	httpClient  *http.Client
+	streaming   bool
+	retryPolicy RetryPolicy
+
+	versionCheck *versionCheck
+
+	// defaultHeaders are sent with every request, unless the request overrides them.
+	defaultHeaders map[httpHeader]string
+
+	limiter        *limiter
+	engineLimiters map[Engine]*limiter
+
+	middlewares []Middleware
+	handler     Handler
+
+	logger *slog.Logger
+
+	archiveLimits ArchiveLimits
+
+	// socketPath is the Unix domain socket of a unix:// hostname.
+	socketPath  string
+	dialContext DialContextFunc
+
+	progress ProgressFunc
+
+	tracePrefix string
+
+	skipValidation bool
 }
 
 // NewClient creates a new gotenberg.Client. If http.Client is passed as nil, then http.DefaultClient is used.
-func NewClient(hostname string, httpClient *http.Client) (*Client, error) {
+// The hostname is a URL which may contain a path prefix and query parameters, e.g., https://gw.internal/gotenberg/,
+// or a unix:///path/to/gotenberg.sock address to reach Gotenberg over a Unix domain socket.
+// Options configure client-wide defaults which are merged into every request; values set on the request itself
+// take precedence.
+func NewClient(hostname string, httpClient *http.Client, opts ...ClientOption) (*Client, error) {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
@@ -41,10 +72,51 @@ func NewClient(hostname string, httpClient *http.Client) (*Client, error) {
 		return nil, errEmptyHostname
 	}
 
-	return &Client{
-		hostname:   hostname,
-		httpClient: httpClient,
-	}, nil
+	socketPath, isUnix, err := parseUnixAddress(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if isUnix {
+		hostname = unixHostname
+	}
+
+	baseURL, err := parseHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:        baseURL,
+		httpClient:     httpClient,
+		defaultHeaders: make(map[httpHeader]string),
+		archiveLimits:  DefaultArchiveLimits(),
+		socketPath:     socketPath,
+	}
+	c.handler = c.roundTrip
+
+	for _, opt := range opts {
+		if err = opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = c.configureDialer(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// WithStreaming makes the client stream multipart bodies to the Gotenberg API instead of buffering them in
+// memory before sending. If the sizes of all documents are known (see document.Sizer), the Content-Length
+// header is set as well; otherwise the body is sent with chunked transfer encoding.
+func WithStreaming() ClientOption {
+	return func(c *Client) error {
+		c.streaming = true
+
+		return nil
+	}
 }
 
 // Send sends a request to the Gotenberg API and returns the response.
@@ -53,87 +125,212 @@ func (c *Client) Send(ctx context.Context, req MultipartRequest) (*http.Response
 }
 
 func (c *Client) send(ctx context.Context, req MultipartRequest) (*http.Response, error) {
-	r, err := c.createRequest(ctx, req, req.endpoint())
+	return c.do(ctx, req, req.endpoint())
+}
+
+// do sends the request to the given endpoint, retrying it according to the client retry policy.
+func (c *Client) do(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Response, error) {
+	start := time.Now()
+	ctx = c.withTrace(ctx, mr)
+
+	resp, attempts, err := c.doWithRetries(ctx, mr, endpoint)
+	c.logConversion(ctx, mr, endpoint, time.Since(start), attempts, resp, err)
+
+	if fn := c.progressOf(mr); fn != nil && err == nil && resp.StatusCode < http.StatusMultipleChoices {
+		trackDownload(resp, fn)
+	}
+
+	return resp, err
+}
+
+func (c *Client) doWithRetries(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Response, int, error) {
+	if err := c.validate(mr); err != nil {
+		return nil, 0, err
+	}
+
+	if err := c.checkVersion(ctx, mr, endpoint); err != nil {
+		return nil, 0, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doOnce(ctx, mr, endpoint)
+		if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.shouldRetry(ctx, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay := c.retryPolicy.backoff(attempt, resp)
+
+		// The outcome of the attempt is reported if the context ends before the next one.
+		lastErr := err
+		if resp != nil {
+			lastErr = newGotenbergError(resp, mr, endpoint)
+			discardResponse(resp)
+		}
+
+		if err = sleepContext(ctx, delay); err != nil {
+			return nil, attempt, fmt.Errorf("%w: %w: last attempt: %w", errSendRequestFailed, err, lastErr)
+		}
+	}
+}
+
+func (c *Client) doOnce(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Response, error) {
+	release, err := c.acquireSlot(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
+	// Gotenberg is done with the request once the response headers are received.
+	defer release()
 
-	resp, err := c.httpClient.Do(r)
+	req, err := c.createRequest(ctx, mr, endpoint)
 	if err != nil {
+		return nil, err
+	}
+
+	t := newTimer()
+
+	resp, err := c.handler(&Call{Request: mr, Endpoint: endpoint, HTTPRequest: t.withTimer(req), timer: t})
+	if err != nil {
+		// A middleware may fail without calling the next handler, in which case the body, e.g., the pipe
+		// of a streaming request, has never been read nor closed.
+		_ = req.Body.Close()
+
 		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
 	}
 
+	if resp.Body == nil {
+		_ = req.Body.Close()
+
+		return resp, nil
+	}
+
+	t.trackBody(resp)
+	closeWithResponse(resp, req.Body)
+
 	return resp, nil
 }
 
-// Store creates the resulting file to given destination.
-func (c *Client) Store(ctx context.Context, req MultipartRequest, dest string) error {
-	return c.store(ctx, req, dest)
+// closeWithResponse closes the request body along with the response body. The transport may still be writing
+// the request body when the response is returned, so it cannot be closed earlier. Closing it twice is harmless.
+func closeWithResponse(resp *http.Response, reqBody io.Closer) {
+	resp.Body = &responseBody{ReadCloser: resp.Body, reqBody: reqBody}
 }
 
-func (c *Client) store(ctx context.Context, req MultipartRequest, dest string) error {
-	if hasWebhook(req) {
+type responseBody struct {
+	io.ReadCloser
+
+	reqBody io.Closer
+}
+
+func (rb *responseBody) Close() error {
+	_ = rb.reqBody.Close()
+
+	return rb.ReadCloser.Close() //nolint:wrapcheck // the body error is returned as is.
+}
+
+// Store creates the resulting file to given destination. The file is written atomically: it either holds
+// the complete result or is left untouched.
+func (c *Client) Store(ctx context.Context, req MultipartRequest, dest string, opts ...StoreOption) error {
+	return c.store(ctx, req, dest, opts...)
+}
+
+func (c *Client) store(ctx context.Context, req MultipartRequest, dest string, opts ...StoreOption) error {
+	if c.hasWebhook(req) {
 		return errWebhookNotAllowed
 	}
 
-	resp, err := c.send(ctx, req)
+	res, err := c.Do(ctx, req)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d", errGenerationFailed, resp.StatusCode)
+	return res.SaveTo(dest, opts...)
+}
+
+// StoreTo writes the resulting file to w, e.g., an http.ResponseWriter, and returns the number of bytes written.
+func (c *Client) StoreTo(ctx context.Context, req MultipartRequest, w io.Writer, opts ...StoreOption) (int64, error) {
+	if c.hasWebhook(req) {
+		return 0, errWebhookNotAllowed
 	}
 
-	return writeNewFile(dest, resp.Body)
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return writeResult(res, w, newStoreOptions(opts))
 }
 
-func writeNewFile(fpath string, in io.Reader) error {
-	if err := os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
-		return fmt.Errorf("making %s directory: %w", fpath, err)
+func (c *Client) createRequest(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Request, error) {
+	if c.streaming {
+		return c.createStreamingRequest(ctx, mr, endpoint)
 	}
 
-	out, err := os.Create(fpath)
+	body, contentType, err := multipartForm(mr, c.progressOf(mr))
 	if err != nil {
-		return fmt.Errorf("creating %s: %w", fpath, err)
+		return nil, err
 	}
-	defer func() {
-		if closeErr := out.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("closing %s: %w", fpath, closeErr)
-		}
-	}()
 
-	if err = out.Chmod(0o644); err != nil && runtime.GOOS != "windows" {
-		return fmt.Errorf("setting %s permissions: %w", fpath, err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpointURL(endpoint), body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	if _, err = io.Copy(out, in); err != nil {
-		return fmt.Errorf("writing to %s: %w", fpath, err)
-	}
+	c.setRequestHeaders(req, mr, contentType)
 
-	return nil
+	return req, nil
 }
 
-func (c *Client) createRequest(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Request, error) {
-	body, contentType, err := multipartForm(mr)
+func (c *Client) createStreamingRequest(ctx context.Context, mr MultipartRequest, endpoint string) (*http.Request, error) {
+	body, contentType, contentLength := streamMultipartForm(mr, c.progressOf(mr))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpointURL(endpoint), body)
 	if err != nil {
-		return nil, err
+		_ = body.Close()
+
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s%s", c.hostname, endpoint)
+	if contentLength >= 0 {
+		req.ContentLength = contentLength
+	}
+
+	c.setRequestHeaders(req, mr, contentType)
+
+	return req, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+// createGetRequest creates a request to one of the Gotenberg routes without a multipart form, e.g., /health.
+func (c *Client) createGetRequest(ctx context.Context, endpoint string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpointURL(endpoint), nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
+	for key, value := range c.defaultHeaders {
+		req.Header.Set(string(key), value)
+	}
+
+	return req, nil
+}
+
+// setRequestHeaders sets the client default headers, then the request headers, so the latter take precedence.
+func (c *Client) setRequestHeaders(req *http.Request, mr MultipartRequest, contentType string) {
+	for key, value := range c.defaultHeaders {
+		req.Header.Set(string(key), value)
+	}
+
 	req.Header.Set("Content-Type", contentType)
+
+	if trace := traceOf(req.Context()); trace != "" {
+		req.Header.Set(string(headerTrace), trace)
+	}
+
 	for key, value := range mr.customHeaders() {
 		req.Header.Set(string(key), value)
 	}
+}
 
-	return req, nil
+// hasWebhook reports whether the request, or the client by default, uses a webhook.
+func (c *Client) hasWebhook(req Request) bool {
+	return hasWebhook(req) || c.defaultHeaders[headerWebhookURL] != ""
 }
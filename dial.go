@@ -0,0 +1,95 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var errDialerNotSupported = errors.New("custom dialer requires an *http.Transport")
+
+const schemeUnix = "unix"
+
+// unixHostname is the hostname used in the URLs of requests sent over a Unix domain socket.
+const unixHostname = "http://localhost"
+
+// DialContextFunc dials a connection to the given address, like net.Dialer.DialContext.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// WithDialer sets the function used to open connections to the Gotenberg API, e.g., to go through a proxy
+// or a service mesh. For a unix:// address, it is called with the "unix" network and the socket path.
+//
+// NOTE: the client transport, http.DefaultTransport by default, must be an *http.Transport. It is cloned, not modified.
+func WithDialer(dial DialContextFunc) ClientOption {
+	return func(c *Client) error {
+		c.dialContext = dial
+
+		return nil
+	}
+}
+
+// parseUnixAddress returns the socket path of a unix:///path/to/gotenberg.sock address.
+func parseUnixAddress(hostname string) (string, bool, error) {
+	if !strings.HasPrefix(hostname, schemeUnix+":") {
+		return "", false, nil
+	}
+
+	u, err := url.Parse(hostname)
+	if err != nil {
+		return "", true, fmt.Errorf("invalid unix address %q: %w", hostname, err)
+	}
+
+	socketPath := u.Path
+	if socketPath == "" {
+		socketPath = u.Opaque
+	}
+
+	if socketPath == "" || u.Host != "" {
+		return "", true, fmt.Errorf("invalid unix address %q: expected unix:///path/to/socket", hostname)
+	}
+
+	return socketPath, true, nil
+}
+
+// configureDialer installs the custom dialer and the Unix domain socket, if any, on a copy of the transport.
+func (c *Client) configureDialer() error {
+	if c.dialContext == nil && c.socketPath == "" {
+		return nil
+	}
+
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("%w, got %T", errDialerNotSupported, base)
+	}
+
+	transport = transport.Clone()
+
+	dial := c.dialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	if socketPath := c.socketPath; socketPath != "" {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dial(ctx, "unix", socketPath)
+		}
+		// Proxies cannot reach a Unix domain socket.
+		transport.Proxy = nil
+	} else {
+		transport.DialContext = dial
+	}
+
+	c.httpClient = cloneHTTPClient(c.httpClient)
+	c.httpClient.Transport = transport
+
+	return nil
+}
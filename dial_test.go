@@ -0,0 +1,88 @@
+package gotenberg
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func unixServer(t *testing.T, handler http.Handler) (*httptest.Server, string) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "gotenberg")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	socketPath := filepath.Join(dir, "gotenberg.sock")
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix domain sockets are not supported: %v", err)
+	}
+
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Listener = l
+	srv.Start()
+
+	return srv, socketPath
+}
+
+func TestClientUnixSocket(t *testing.T) {
+	var path string
+
+	srv, socketPath := unixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte("%PDF-"))
+	}))
+	defer srv.Close()
+
+	var dials atomic.Int32
+
+	c, err := NewClient("unix://"+socketPath, nil, WithDialer(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		assert.Equal(t, "unix", network)
+		assert.Equal(t, socketPath, addr)
+
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}))
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	res, err := c.Do(context.Background(), NewHTMLRequest(index))
+	require.NoError(t, err)
+
+	data, err := res.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-", string(data))
+	assert.Equal(t, endpointHTMLConvert, path)
+	assert.Equal(t, int32(1), dials.Load())
+	assert.NotSame(t, http.DefaultClient, c.httpClient, "the default client must not be modified")
+}
+
+func TestClientDialerErrors(t *testing.T) {
+	_, err := NewClient("unix://host/gotenberg.sock", nil)
+	require.Error(t, err)
+
+	_, err = NewClient("unix://", nil)
+	require.Error(t, err)
+
+	transport := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, nil //nolint:nilnil // never called.
+	})
+	_, err = NewClient("unix:///run/gotenberg.sock", nil, WithTransport(transport))
+	require.ErrorIs(t, err, errDialerNotSupported)
+}
@@ -0,0 +1,165 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var errClosed = errors.New("buffered document closed")
+
+// DefaultMaxMemory is the size above which a buffered document spills to a temporary file, unless set by WithBuffer.
+const DefaultMaxMemory = 32 << 20
+
+// ReaderOption configures a Document created with FromReader.
+type ReaderOption func(o *readerOptions)
+
+type readerOptions struct {
+	buffered  bool
+	maxMemory int64
+	tempDir   string
+}
+
+// WithBuffer makes FromReader read the whole reader upfront, so that the document can be read any number
+// of times, e.g., by retries. Up to maxMemory bytes are kept in memory, the rest is written to a temporary
+// file which is removed by Close. A zero or negative maxMemory means DefaultMaxMemory.
+func WithBuffer(maxMemory int64) ReaderOption {
+	return func(o *readerOptions) {
+		o.buffered = true
+
+		o.maxMemory = maxMemory
+		if maxMemory <= 0 {
+			o.maxMemory = DefaultMaxMemory
+		}
+	}
+}
+
+// WithTempDir sets the directory of the temporary files of WithBuffer, os.TempDir by default.
+func WithTempDir(dir string) ReaderOption {
+	return func(o *readerOptions) {
+		o.tempDir = dir
+	}
+}
+
+// bufferedDocument holds the content of a reader in memory or, above the memory limit, in a temporary file.
+type bufferedDocument struct {
+	mu     sync.RWMutex
+	data   []byte
+	fpath  string
+	size   int64
+	closed bool
+
+	*document
+}
+
+func newBufferedDocument(fname string, r io.Reader, o readerOptions) (*bufferedDocument, error) {
+	doc := &bufferedDocument{document: &document{filename: fname}}
+
+	var buf bytes.Buffer
+
+	n, err := io.Copy(&buf, io.LimitReader(r, o.maxMemory+1))
+	if err != nil {
+		return nil, fmt.Errorf("buffering %s: %w", fname, err)
+	}
+
+	if n <= o.maxMemory {
+		doc.data = buf.Bytes()
+		doc.size = n
+
+		return doc, nil
+	}
+
+	if err = doc.spill(&buf, r, o.tempDir); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// spill writes the buffered bytes, then the rest of the reader, to a temporary file.
+func (doc *bufferedDocument) spill(buf *bytes.Buffer, r io.Reader, dir string) error {
+	f, err := os.CreateTemp(dir, "gotenberg-document-*")
+	if err != nil {
+		return fmt.Errorf("buffering %s: %w", doc.Filename(), err)
+	}
+
+	n, err := io.Copy(f, io.MultiReader(buf, r))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+
+		return fmt.Errorf("buffering %s: %w", doc.Filename(), err)
+	}
+
+	doc.fpath = f.Name()
+	doc.size = n
+
+	return nil
+}
+
+func (doc *bufferedDocument) Reader() (io.ReadCloser, error) {
+	doc.mu.RLock()
+	defer doc.mu.RUnlock()
+
+	if doc.closed {
+		return nil, fmt.Errorf("%s: %w", doc.Filename(), errClosed)
+	}
+
+	if doc.fpath == "" {
+		return io.NopCloser(bytes.NewReader(doc.data)), nil
+	}
+
+	in, err := os.Open(doc.fpath)
+	if err != nil {
+		return nil, fmt.Errorf("opening file %s: %w", doc.Filename(), err)
+	}
+
+	return in, nil
+}
+
+func (doc *bufferedDocument) Size() (int64, error) {
+	return doc.size, nil
+}
+
+// Close releases the buffer and removes the temporary file, if any. The document cannot be read afterward.
+func (doc *bufferedDocument) Close() error {
+	doc.mu.Lock()
+	defer doc.mu.Unlock()
+
+	if doc.closed {
+		return nil
+	}
+
+	doc.closed = true
+	doc.data = nil
+
+	if doc.fpath == "" {
+		return nil
+	}
+
+	if err := os.Remove(doc.fpath); err != nil {
+		return fmt.Errorf("removing %s temporary file: %w", doc.Filename(), err)
+	}
+
+	return nil
+}
+
+// Cleanup closes the documents which hold resources, such as the temporary files of documents created
+// with FromReader and WithBuffer. Other documents are left as is.
+func Cleanup(docs ...Document) error {
+	var errs []error
+
+	for _, doc := range docs {
+		if closer, ok := doc.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Digester is implemented by documents whose SHA-256 digest can be computed without consuming them.
+type Digester interface {
+	// Digest returns the hex-encoded SHA-256 digest of the content.
+	Digest() (string, error)
+}
+
+// SizeOf returns the size of the document in bytes. Documents which do not implement Sizer are read entirely,
+// except documents created with FromReader without WithBuffer, which can only be read once: SizeOf returns -1
+// for them.
+func SizeOf(doc Document) (int64, error) {
+	if sizer, ok := doc.(Sizer); ok {
+		return sizer.Size() //nolint:wrapcheck // errors already name the document.
+	}
+
+	if _, ok := doc.(*documentFromReader); ok {
+		return -1, nil
+	}
+
+	in, err := doc.Reader()
+	if err != nil {
+		return 0, fmt.Errorf("getting %s reader: %w", doc.Filename(), err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	n, err := io.Copy(io.Discard, in)
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %w", doc.Filename(), err)
+	}
+
+	return n, nil
+}
+
+// DigestOf returns the hex-encoded SHA-256 digest of the document. Documents which do not implement Digester
+// are read entirely, except documents created with FromReader without WithBuffer, which can only be read once:
+// DigestOf returns an empty string for them.
+func DigestOf(doc Document) (string, error) {
+	if digester, ok := doc.(Digester); ok {
+		return digester.Digest() //nolint:wrapcheck // errors already name the document.
+	}
+
+	if _, ok := doc.(*documentFromReader); ok {
+		return "", nil
+	}
+
+	return digest(doc)
+}
+
+func digest(doc Document) (string, error) {
+	in, err := doc.Reader()
+	if err != nil {
+		return "", fmt.Errorf("getting %s reader: %w", doc.Filename(), err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, in); err != nil {
+		return "", fmt.Errorf("reading %s: %w", doc.Filename(), err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cachedDigest computes the digest once, for documents whose content never changes.
+func (doc *document) cachedDigest(compute func() (string, error)) (string, error) {
+	doc.digestOnce.Do(func() {
+		doc.digest, doc.digestErr = compute()
+	})
+
+	return doc.digest, doc.digestErr
+}
+
+// Digest reads the file on each call, as it may change.
+func (doc *documentFromPath) Digest() (string, error) {
+	return digest(doc)
+}
+
+// Digest reads the file on each call, as the file system may change.
+func (doc *documentFromFS) Digest() (string, error) {
+	return digest(doc)
+}
+
+func (doc *documentFromString) Digest() (string, error) {
+	return doc.cachedDigest(func() (string, error) {
+		sum := sha256.Sum256([]byte(doc.data))
+
+		return hex.EncodeToString(sum[:]), nil
+	})
+}
+
+func (doc *documentFromBytes) Digest() (string, error) {
+	return doc.cachedDigest(func() (string, error) {
+		sum := sha256.Sum256(doc.data)
+
+		return hex.EncodeToString(sum[:]), nil
+	})
+}
+
+func (doc *bufferedDocument) Digest() (string, error) {
+	return doc.cachedDigest(func() (string, error) {
+		return digest(doc)
+	})
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Digester(new(documentFromPath))
+	_ = Digester(new(documentFromString))
+	_ = Digester(new(documentFromBytes))
+	_ = Digester(new(documentFromFS))
+	_ = Digester(new(bufferedDocument))
+)
@@ -0,0 +1,128 @@
+package document
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DirOption configures the documents loaded by FromDir.
+type DirOption func(o *dirOptions)
+
+type dirOptions struct {
+	include  []string
+	exclude  []string
+	maxDepth int
+}
+
+// WithInclude only loads the files matching at least one of the patterns.
+// See FromDir for the syntax of the patterns.
+func WithInclude(patterns ...string) DirOption {
+	return func(o *dirOptions) {
+		o.include = append(o.include, patterns...)
+	}
+}
+
+// WithExclude skips the files and directories matching at least one of the patterns.
+// See FromDir for the syntax of the patterns.
+func WithExclude(patterns ...string) DirOption {
+	return func(o *dirOptions) {
+		o.exclude = append(o.exclude, patterns...)
+	}
+}
+
+// WithMaxDepth limits how deep FromDir walks: 1 only loads the files of the directory itself,
+// 2 those of its subdirectories too, and so on. Zero or a negative depth means no limit, the default.
+func WithMaxDepth(depth int) DirOption {
+	return func(o *dirOptions) {
+		o.maxDepth = depth
+	}
+}
+
+// FromDir walks the directory dir and returns a document for each regular file, in lexical order. Each document
+// is named by its path relative to dir, with forward slashes, e.g., "css/style.css".
+//
+// Patterns have the syntax of path.Match. A pattern containing a slash is matched against the relative path,
+// otherwise against the base name, so "*.css" matches "style.css" and "css/style.css".
+func FromDir(dir string, opts ...DirOption) ([]Document, error) {
+	var o dirOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for _, pattern := range append(o.include, o.exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+	}
+
+	var docs []Document
+
+	err := filepath.WalkDir(dir, func(fpath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, fpath)
+		if err != nil {
+			return fmt.Errorf("getting %s relative path: %w", fpath, err)
+		}
+
+		if rel == "." {
+			return nil
+		}
+
+		name := filepath.ToSlash(rel)
+		depth := strings.Count(name, "/") + 1
+
+		if entry.IsDir() {
+			if matchAny(o.exclude, name) || (o.maxDepth > 0 && depth >= o.maxDepth) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() || matchAny(o.exclude, name) {
+			return nil
+		}
+
+		if len(o.include) > 0 && !matchAny(o.include, name) {
+			return nil
+		}
+
+		docs = append(docs, &documentFromPath{
+			fpath:    fpath,
+			document: &document{filename: name},
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", dir, err)
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, errNoMatch)
+	}
+
+	return docs, nil
+}
+
+// matchAny reports whether the relative path name matches one of the patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,15 +1,25 @@
 package document
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-var errEmptyContent = errors.New("empty content passed")
+var (
+	errEmptyContent = errors.New("empty content passed")
+	errIsDir        = errors.New("is a directory")
+	errNoMatch      = errors.New("no file matches the pattern")
+	errAlreadyRead  = errors.New("document from reader already read; use WithBuffer to read it several times")
+)
 
 // Document represents a file which will be sent to the Gotenberg API.
 type Document interface {
@@ -17,8 +27,22 @@ type Document interface {
 	Reader() (io.ReadCloser, error)
 }
 
+// Sizer is implemented by documents whose size in bytes is known without reading them.
+type Sizer interface {
+	Size() (int64, error)
+}
+
 type document struct {
 	filename string
+
+	// The content type is detected once, see ContentTyper.
+	sniffOnce   sync.Once
+	contentType string
+	sniffErr    error
+
+	digestOnce sync.Once
+	digest     string
+	digestErr  error
 }
 
 func (doc *document) Filename() string {
@@ -52,6 +76,15 @@ func (doc *documentFromPath) Reader() (io.ReadCloser, error) {
 	return in, nil
 }
 
+func (doc *documentFromPath) Size() (int64, error) {
+	info, err := os.Stat(doc.fpath)
+	if err != nil {
+		return 0, fmt.Errorf("getting file %s info: %w", doc.Filename(), err)
+	}
+
+	return info.Size(), nil
+}
+
 type documentFromString struct {
 	data string
 
@@ -66,7 +99,7 @@ func FromString(fname, data string) (Document, error) {
 
 	return &documentFromString{
 		data:     data,
-		document: &document{fname},
+		document: &document{filename: fname},
 	}, nil
 }
 
@@ -74,6 +107,10 @@ func (doc *documentFromString) Reader() (io.ReadCloser, error) {
 	return io.NopCloser(strings.NewReader(doc.data)), nil
 }
 
+func (doc *documentFromString) Size() (int64, error) {
+	return int64(len(doc.data)), nil
+}
+
 type documentFromBytes struct {
 	data []byte
 
@@ -88,7 +125,7 @@ func FromBytes(fname string, data []byte) (Document, error) {
 
 	return &documentFromBytes{
 		data:     data,
-		document: &document{fname},
+		document: &document{filename: fname},
 	}, nil
 }
 
@@ -96,28 +133,122 @@ func (doc *documentFromBytes) Reader() (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(doc.data)), nil
 }
 
+func (doc *documentFromBytes) Size() (int64, error) {
+	return int64(len(doc.data)), nil
+}
+
 type documentFromReader struct {
-	r io.Reader
+	r    *bufio.Reader
+	read atomic.Bool
 
 	*document
 }
 
-// FromReader creates a Document from a reader.
-func FromReader(fname string, r io.Reader) (Document, error) {
+// FromReader creates a Document from a reader. By default, the reader can only be read once: a second
+// Reader call, e.g., when a request is retried or sent again, fails. With WithBuffer, the content is
+// buffered so that it can be read any number of times.
+func FromReader(fname string, r io.Reader, opts ...ReaderOption) (Document, error) {
 	if r == nil {
 		return nil, fmt.Errorf("%s: %w", fname, errEmptyContent)
 	}
 
+	o := readerOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.buffered {
+		return newBufferedDocument(fname, r, o)
+	}
+
 	return &documentFromReader{
-		r:        r,
-		document: &document{fname},
+		r:        bufio.NewReaderSize(r, SniffLen),
+		document: &document{filename: fname},
 	}, nil
 }
 
 func (doc *documentFromReader) Reader() (io.ReadCloser, error) {
+	if doc.read.Swap(true) {
+		return nil, fmt.Errorf("%s: %w", doc.Filename(), errAlreadyRead)
+	}
+
 	return io.NopCloser(doc.r), nil
 }
 
+type documentFromFS struct {
+	fsys fs.FS
+	name string
+
+	*document
+}
+
+// FromFS creates a Document from a file of a file system, e.g., an embed.FS. The filename is the base name
+// of the file. The file is opened on each Reader call.
+func FromFS(fsys fs.FS, name string) (Document, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("getting file %s info: %w", name, err)
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s: %w", name, errIsDir)
+	}
+
+	return &documentFromFS{
+		fsys:     fsys,
+		name:     name,
+		document: &document{filename: path.Base(name)},
+	}, nil
+}
+
+// FromFSGlob creates a Document for each file of a file system matching the pattern, with the syntax of
+// path.Match, e.g., "assets/*.css". Directories are skipped.
+func FromFSGlob(fsys fs.FS, pattern string) ([]Document, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matching %s: %w", pattern, err)
+	}
+
+	docs := make([]Document, 0, len(names))
+
+	for _, name := range names {
+		doc, err := FromFS(fsys, name)
+		if errors.Is(err, errIsDir) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%s: %w", pattern, errNoMatch)
+	}
+
+	return docs, nil
+}
+
+func (doc *documentFromFS) Reader() (io.ReadCloser, error) {
+	in, err := doc.fsys.Open(doc.name)
+	if err != nil {
+		return nil, fmt.Errorf("opening file %s: %w", doc.name, err)
+	}
+
+	return in, nil
+}
+
+func (doc *documentFromFS) Size() (int64, error) {
+	info, err := fs.Stat(doc.fsys, doc.name)
+	if err != nil {
+		return 0, fmt.Errorf("getting file %s info: %w", doc.name, err)
+	}
+
+	return info.Size(), nil
+}
+
 func fileExists(name string) bool {
 	_, err := os.Stat(name)
 
@@ -130,4 +261,14 @@ var (
 	_ = Document(new(documentFromString))
 	_ = Document(new(documentFromBytes))
 	_ = Document(new(documentFromReader))
+	_ = Document(new(documentFromFS))
+	_ = Document(new(bufferedDocument))
+
+	_ = Sizer(new(documentFromPath))
+	_ = Sizer(new(documentFromString))
+	_ = Sizer(new(documentFromBytes))
+	_ = Sizer(new(documentFromFS))
+	_ = Sizer(new(bufferedDocument))
+
+	_ = io.Closer(new(bufferedDocument))
 )
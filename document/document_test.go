@@ -2,10 +2,14 @@ package document
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestFromPath(t *testing.T) {
@@ -194,6 +198,21 @@ func TestFromReader(t *testing.T) {
 		}
 	})
 
+	t.Run("ReadTwice", func(t *testing.T) {
+		doc, err := FromReader("testfile.txt", strings.NewReader("this is test content"))
+		if err != nil {
+			t.Fatalf("FromReader failed for valid reader: %v", err)
+		}
+
+		if _, err = doc.Reader(); err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+
+		if _, err = doc.Reader(); !errors.Is(err, errAlreadyRead) {
+			t.Errorf("expected errAlreadyRead, got %v", err)
+		}
+	})
+
 	t.Run("NilReader", func(t *testing.T) {
 		filename := "nilreader.txt"
 
@@ -203,3 +222,338 @@ func TestFromReader(t *testing.T) {
 		}
 	})
 }
+
+func TestFromReaderWithBuffer(t *testing.T) {
+	const data = "this is test content"
+
+	for name, maxMemory := range map[string]int64{"Memory": 1024, "TempFile": 4} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			doc, err := FromReader("testfile.txt", strings.NewReader(data), WithBuffer(maxMemory), WithTempDir(dir))
+			if err != nil {
+				t.Fatalf("FromReader failed for valid reader: %v", err)
+			}
+
+			for range 2 {
+				reader, err := doc.Reader()
+				if err != nil {
+					t.Fatalf("Reader failed: %v", err)
+				}
+
+				readData, err := io.ReadAll(reader)
+				_ = reader.Close()
+
+				if err != nil || string(readData) != data {
+					t.Errorf("expected data %q, got %q (%v)", data, string(readData), err)
+				}
+			}
+
+			if size, _ := doc.(Sizer).Size(); size != int64(len(data)) {
+				t.Errorf("expected size %d, got %d", len(data), size)
+			}
+
+			entries, _ := os.ReadDir(dir)
+			if spilled := len(entries) == 1; spilled != (name == "TempFile") {
+				t.Errorf("expected the content to spill to a temporary file only above the limit, got %d files", len(entries))
+			}
+
+			if err = Cleanup(doc); err != nil {
+				t.Fatalf("Cleanup failed: %v", err)
+			}
+
+			if entries, _ = os.ReadDir(dir); len(entries) != 0 {
+				t.Errorf("expected the temporary file to be removed, got %d files", len(entries))
+			}
+
+			if _, err = doc.Reader(); !errors.Is(err, errClosed) {
+				t.Errorf("expected errClosed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/index.html": {Data: []byte("<html>Foo</html>")},
+		"assets/style.css":     {Data: []byte("body {}")},
+		"assets/font.woff2":    {Data: []byte("wOF2")},
+		"assets/fonts":         {Mode: fs.ModeDir},
+	}
+
+	t.Run("ValidFile", func(t *testing.T) {
+		doc, err := FromFS(fsys, "templates/index.html")
+		if err != nil {
+			t.Fatalf("FromFS failed for existing file: %v", err)
+		}
+
+		if doc.Filename() != "index.html" {
+			t.Errorf("expected filename index.html, got %s", doc.Filename())
+		}
+
+		// The file is opened on each call.
+		for range 2 {
+			reader, err := doc.Reader()
+			if err != nil {
+				t.Fatalf("Reader failed: %v", err)
+			}
+
+			data, err := io.ReadAll(reader)
+			_ = reader.Close()
+
+			if err != nil || string(data) != "<html>Foo</html>" {
+				t.Errorf("expected data %q, got %q (%v)", "<html>Foo</html>", string(data), err)
+			}
+		}
+
+		size, err := doc.(Sizer).Size()
+		if err != nil || size != 16 {
+			t.Errorf("expected size 16, got %d (%v)", size, err)
+		}
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		if _, err := FromFS(fsys, "templates/missing.html"); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+
+		if _, err := FromFS(fsys, "assets"); err == nil {
+			t.Errorf("expected error for directory, got nil")
+		}
+	})
+
+	t.Run("Glob", func(t *testing.T) {
+		docs, err := FromFSGlob(fsys, "assets/*")
+		if err != nil {
+			t.Fatalf("FromFSGlob failed: %v", err)
+		}
+
+		names := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			names = append(names, doc.Filename())
+		}
+
+		if strings.Join(names, ",") != "font.woff2,style.css" {
+			t.Errorf("expected font.woff2 and style.css, got %v", names)
+		}
+
+		if _, err = FromFSGlob(fsys, "assets/*.js"); err == nil {
+			t.Errorf("expected error for pattern without match, got nil")
+		}
+	})
+}
+
+func TestSizer(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "testfile-*.txt")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(tmpFile.Name())
+
+	if _, err = tmpFile.WriteString("this is test content"); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	fromPath, _ := FromPath("testfile.txt", tmpFile.Name())
+	fromString, _ := FromString("testfile.txt", "this is test content")
+	fromBytes, _ := FromBytes("testfile.txt", []byte("this is test content"))
+
+	for _, doc := range []Document{fromPath, fromString, fromBytes} {
+		sizer, ok := doc.(Sizer)
+		if !ok {
+			t.Fatalf("expected %T to implement Sizer", doc)
+		}
+
+		size, err := sizer.Size()
+		if err != nil {
+			t.Fatalf("Size failed: %v", err)
+		}
+
+		if size != 20 {
+			t.Errorf("expected size 20, got %d", size)
+		}
+	}
+
+	fromReader, _ := FromReader("testfile.txt", strings.NewReader("this is test content"))
+	if _, ok := fromReader.(Sizer); ok {
+		t.Errorf("expected reader document not to implement Sizer")
+	}
+}
+
+type customDocument struct {
+	data string
+}
+
+func (doc *customDocument) Filename() string {
+	return "custom.txt"
+}
+
+func (doc *customDocument) Reader() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(doc.data)), nil
+}
+
+func TestDigester(t *testing.T) {
+	const (
+		data     = "this is test content"
+		expected = "933739846e5a7e1641795515674b89f603824bbfaf42f77ca2183c179dd6d2b1"
+	)
+
+	tmpFile, err := os.CreateTemp("", "testfile-*.txt")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(tmpFile.Name())
+
+	if _, err = tmpFile.WriteString(data); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	_ = tmpFile.Close()
+
+	fromPath, _ := FromPath("testfile.txt", tmpFile.Name())
+	fromString, _ := FromString("testfile.txt", data)
+	fromBytes, _ := FromBytes("testfile.txt", []byte(data))
+	buffered, _ := FromReader("testfile.txt", strings.NewReader(data), WithBuffer(0))
+
+	for _, doc := range []Document{fromPath, fromString, fromBytes, buffered, &customDocument{data: data}} {
+		digest, err := DigestOf(doc)
+		if err != nil || digest != expected {
+			t.Errorf("%T: expected digest %s, got %s (%v)", doc, expected, digest, err)
+		}
+
+		size, err := SizeOf(doc)
+		if err != nil || size != int64(len(data)) {
+			t.Errorf("%T: expected size %d, got %d (%v)", doc, len(data), size, err)
+		}
+	}
+
+	// A one-shot document is left unread.
+	fromReader, _ := FromReader("testfile.txt", strings.NewReader(data))
+	if digest, err := DigestOf(fromReader); err != nil || digest != "" {
+		t.Errorf("expected no digest for a reader document, got %q (%v)", digest, err)
+	}
+
+	if size, err := SizeOf(fromReader); err != nil || size != -1 {
+		t.Errorf("expected unknown size for a reader document, got %d (%v)", size, err)
+	}
+
+	if _, err = fromReader.Reader(); err != nil {
+		t.Errorf("expected the reader document to be readable after inspection, got %v", err)
+	}
+}
+
+func TestFromDir(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, data := range map[string]string{
+		"index.html":            "<html>Foo</html>",
+		"css/style.css":         "body {}",
+		"css/vendor/reset.css":  "* {}",
+		"img/logo.png":          "\x89PNG",
+		"node_modules/x/lib.js": "alert(1);",
+	} {
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(fpath, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(docs []Document) string {
+		fnames := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			fnames = append(fnames, doc.Filename())
+		}
+
+		return strings.Join(fnames, ",")
+	}
+
+	tests := []struct {
+		name     string
+		opts     []DirOption
+		expected string
+	}{
+		{
+			name:     "AllFiles",
+			expected: "css/style.css,css/vendor/reset.css,img/logo.png,index.html,node_modules/x/lib.js",
+		},
+		{
+			name:     "Include",
+			opts:     []DirOption{WithInclude("*.css", "*.html")},
+			expected: "css/style.css,css/vendor/reset.css,index.html",
+		},
+		{
+			name:     "IncludePath",
+			opts:     []DirOption{WithInclude("css/*.css")},
+			expected: "css/style.css",
+		},
+		{
+			name:     "Exclude",
+			opts:     []DirOption{WithExclude("node_modules", "*.png")},
+			expected: "css/style.css,css/vendor/reset.css,index.html",
+		},
+		{
+			name:     "MaxDepth",
+			opts:     []DirOption{WithMaxDepth(2)},
+			expected: "css/style.css,img/logo.png,index.html",
+		},
+		{
+			name:     "RootOnly",
+			opts:     []DirOption{WithMaxDepth(1)},
+			expected: "index.html",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			docs, err := FromDir(dir, tc.opts...)
+			if err != nil {
+				t.Fatalf("FromDir failed: %v", err)
+			}
+
+			if got := names(docs); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("Content", func(t *testing.T) {
+		docs, err := FromDir(dir, WithInclude("css/style.css"))
+		if err != nil {
+			t.Fatalf("FromDir failed: %v", err)
+		}
+
+		reader, err := docs[0].Reader()
+		if err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+
+		data, err := io.ReadAll(reader)
+		_ = reader.Close()
+
+		if err != nil || string(data) != "body {}" {
+			t.Errorf("expected data %q, got %q (%v)", "body {}", string(data), err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := FromDir(dir, WithInclude("*.js"), WithExclude("node_modules")); !errors.Is(err, errNoMatch) {
+			t.Errorf("expected errNoMatch, got %v", err)
+		}
+
+		if _, err := FromDir(filepath.Join(dir, "missing")); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+
+		if _, err := FromDir(dir, WithInclude("[")); err == nil {
+			t.Errorf("expected error for invalid pattern, got nil")
+		}
+	})
+}
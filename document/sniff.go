@@ -0,0 +1,211 @@
+package document
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// SniffLen is the number of bytes read from the beginning of a document to detect its content type.
+const SniffLen = 4096
+
+// Media types detected by Sniff, besides those of http.DetectContentType (e.g., images) and the
+// application/vnd.oasis.opendocument.* types of OpenDocument files.
+const (
+	ContentTypePDF  = "application/pdf"
+	ContentTypeHTML = "text/html"
+	ContentTypeText = "text/plain"
+	ContentTypeZip  = "application/zip"
+	ContentTypeRTF  = "application/rtf"
+	// ContentTypeOLE is a legacy Microsoft Office document, e.g., .doc, .xls or .ppt.
+	ContentTypeOLE  = "application/x-ole-storage"
+	ContentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	ContentTypePPTX = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	// ContentTypeOOXML is an Office Open XML document whose kind could not be determined from its first bytes.
+	ContentTypeOOXML = "application/vnd.openxmlformats-officedocument"
+)
+
+const contentTypeODFPrefix = "application/vnd.oasis.opendocument."
+
+// ContentTyper is implemented by documents whose content type can be detected from their first bytes,
+// which is the case of all the documents of this package. Documents created with FromPath and FromFS are sniffed
+// on each call, the others once.
+type ContentTyper interface {
+	// ContentType returns the detected media type, e.g., application/pdf, or an empty string if it is unknown.
+	ContentType() (string, error)
+}
+
+// ContentTypeOf returns the content type of the document, or an empty string if it does not implement ContentTyper.
+func ContentTypeOf(doc Document) (string, error) {
+	typer, ok := doc.(ContentTyper)
+	if !ok {
+		return "", nil
+	}
+
+	return typer.ContentType() //nolint:wrapcheck // errors already name the document.
+}
+
+// IsOffice reports whether the content type is an office document: Office Open XML, OpenDocument, legacy
+// Microsoft Office or RTF.
+func IsOffice(contentType string) bool {
+	return strings.HasPrefix(contentType, ContentTypeOOXML) || strings.HasPrefix(contentType, contentTypeODFPrefix) ||
+		contentType == ContentTypeOLE || contentType == ContentTypeRTF
+}
+
+// IsImage reports whether the content type is an image.
+func IsImage(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/")
+}
+
+//nolint:gochecknoglobals // read-only signatures.
+var (
+	signatureOLE = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	signatureRTF = []byte(`{\rtf`)
+	signatureZip = []byte("PK\x03\x04")
+)
+
+// Sniff detects the media type of a file from its first bytes, ideally SniffLen of them. It returns an empty
+// string if the content type is unknown.
+func Sniff(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, signatureOLE):
+		return ContentTypeOLE
+	case bytes.HasPrefix(head, signatureRTF):
+		return ContentTypeRTF
+	case bytes.HasPrefix(head, signatureZip):
+		return sniffZip(head)
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || contentType == "application/octet-stream" {
+		return ""
+	}
+
+	return contentType
+}
+
+// sniffZip tells OpenDocument and Office Open XML files apart from other ZIP archives.
+func sniffZip(head []byte) string {
+	// An OpenDocument file starts with an uncompressed "mimetype" entry holding its media type.
+	const localHeaderLen = 30
+
+	if len(head) > localHeaderLen {
+		size := int(binary.LittleEndian.Uint32(head[18:22]))
+		nameLen := int(binary.LittleEndian.Uint16(head[26:28]))
+		extraLen := int(binary.LittleEndian.Uint16(head[28:30]))
+		start := localHeaderLen + nameLen + extraLen
+
+		if nameLen == len("mimetype") && len(head) >= start &&
+			string(head[localHeaderLen:localHeaderLen+nameLen]) == "mimetype" {
+			content := head[start:]
+			if size > 0 && size <= len(content) {
+				content = content[:size]
+			} else if end := bytes.Index(content, []byte("PK")); end >= 0 {
+				// The size is stored after the content, in a data descriptor.
+				content = content[:end]
+			}
+
+			if contentType := string(content); strings.HasPrefix(contentType, contentTypeODFPrefix) {
+				return contentType
+			}
+		}
+	}
+
+	// Office Open XML parts are stored in a directory named after the application.
+	switch {
+	case bytes.Contains(head, []byte("word/")):
+		return ContentTypeDOCX
+	case bytes.Contains(head, []byte("xl/")):
+		return ContentTypeXLSX
+	case bytes.Contains(head, []byte("ppt/")):
+		return ContentTypePPTX
+	case bytes.Contains(head, []byte("[Content_Types].xml")):
+		return ContentTypeOOXML
+	default:
+		return ContentTypeZip
+	}
+}
+
+// sniff detects the content type of the document once, from the first bytes of the reader returned by open.
+func (doc *document) sniff(open func() (io.ReadCloser, error)) (string, error) {
+	doc.sniffOnce.Do(func() {
+		doc.contentType, doc.sniffErr = sniffReader(doc.filename, open)
+	})
+
+	return doc.contentType, doc.sniffErr
+}
+
+// sniffReader detects the content type from the first bytes of the reader returned by open.
+func sniffReader(fname string, open func() (io.ReadCloser, error)) (string, error) {
+	in, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	head := make([]byte, SniffLen)
+
+	n, err := io.ReadFull(in, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading %s: %w", fname, err)
+	}
+
+	return Sniff(head[:n]), nil
+}
+
+// ContentType reads the file on each call, as the file may change.
+func (doc *documentFromPath) ContentType() (string, error) {
+	return sniffReader(doc.filename, doc.Reader)
+}
+
+func (doc *documentFromString) ContentType() (string, error) {
+	return doc.sniff(doc.Reader)
+}
+
+func (doc *documentFromBytes) ContentType() (string, error) {
+	return doc.sniff(doc.Reader)
+}
+
+// ContentType reads the file on each call, as the file system may change.
+func (doc *documentFromFS) ContentType() (string, error) {
+	return sniffReader(doc.filename, doc.Reader)
+}
+
+func (doc *bufferedDocument) ContentType() (string, error) {
+	return doc.sniff(doc.Reader)
+}
+
+// ContentType peeks at the first bytes of the reader, which are still returned by Reader.
+func (doc *documentFromReader) ContentType() (string, error) {
+	return doc.sniff(func() (io.ReadCloser, error) {
+		if doc.read.Load() {
+			return nil, fmt.Errorf("%s: %w", doc.Filename(), errAlreadyRead)
+		}
+
+		head, err := doc.r.Peek(SniffLen)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+			return nil, fmt.Errorf("reading %s: %w", doc.Filename(), err)
+		}
+
+		return io.NopCloser(bytes.NewReader(head)), nil
+	})
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = ContentTyper(new(documentFromPath))
+	_ = ContentTyper(new(documentFromString))
+	_ = ContentTyper(new(documentFromBytes))
+	_ = ContentTyper(new(documentFromReader))
+	_ = ContentTyper(new(documentFromFS))
+	_ = ContentTyper(new(bufferedDocument))
+)
@@ -0,0 +1,127 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func zipBytes(t *testing.T, entries ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < len(entries); i += 2 {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entries[i], Method: zip.Store})
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		if _, err = w.Write([]byte(entries[i+1])); err != nil {
+			t.Fatalf("failed to write zip entry: %v", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     []byte
+		expected string
+	}{
+		{"PDF", []byte("%PDF-1.7\n"), ContentTypePDF},
+		{"HTML", []byte("  <!DOCTYPE html><html></html>"), ContentTypeHTML},
+		{"Text", []byte("# Title"), ContentTypeText},
+		{"PNG", []byte("\x89PNG\r\n\x1a\n\x00\x00"), "image/png"},
+		{"RTF", []byte(`{\rtf1\ansi`), ContentTypeRTF},
+		{"OLE", []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1, 0x00}, ContentTypeOLE},
+		{"DOCX", zipBytes(t, "[Content_Types].xml", "<Types/>", "word/document.xml", "<w:document/>"), ContentTypeDOCX},
+		{"XLSX", zipBytes(t, "[Content_Types].xml", "<Types/>", "xl/workbook.xml", "<workbook/>"), ContentTypeXLSX},
+		{"ODT", zipBytes(t, "mimetype", "application/vnd.oasis.opendocument.text"), "application/vnd.oasis.opendocument.text"},
+		{"Zip", zipBytes(t, "foo.txt", "foo"), ContentTypeZip},
+		{"Unknown", []byte{0x00, 0x01, 0x02, 0x03}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Sniff(tc.head); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	if !IsOffice(ContentTypeDOCX) || !IsOffice("application/vnd.oasis.opendocument.text") || IsOffice(ContentTypePDF) {
+		t.Errorf("unexpected IsOffice results")
+	}
+
+	if !IsImage("image/png") || IsImage(ContentTypePDF) {
+		t.Errorf("unexpected IsImage results")
+	}
+}
+
+func TestContentType(t *testing.T) {
+	t.Run("FromBytes", func(t *testing.T) {
+		doc, _ := FromBytes("foo.pdf", []byte("%PDF-1.7"))
+
+		contentType, err := ContentTypeOf(doc)
+		if err != nil || contentType != ContentTypePDF {
+			t.Errorf("expected %q, got %q (%v)", ContentTypePDF, contentType, err)
+		}
+	})
+
+	t.Run("FromReader", func(t *testing.T) {
+		data := "<html>" + strings.Repeat("Foo", SniffLen) + "</html>"
+		doc, _ := FromReader("index.html", strings.NewReader(data))
+
+		contentType, err := ContentTypeOf(doc)
+		if err != nil || contentType != ContentTypeHTML {
+			t.Errorf("expected %q, got %q (%v)", ContentTypeHTML, contentType, err)
+		}
+
+		// Sniffing must not consume the one-shot reader.
+		reader, err := doc.Reader()
+		if err != nil {
+			t.Fatalf("Reader failed: %v", err)
+		}
+
+		readData, _ := io.ReadAll(reader)
+		if string(readData) != data {
+			t.Errorf("expected the whole content to be read after sniffing, got %d bytes", len(readData))
+		}
+	})
+
+	t.Run("FromPathChanged", func(t *testing.T) {
+		fpath := filepath.Join(t.TempDir(), "document")
+		if err := os.WriteFile(fpath, []byte("%PDF-1.7"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		doc, _ := FromPath("document", fpath)
+
+		contentType, err := ContentTypeOf(doc)
+		if err != nil || contentType != ContentTypePDF {
+			t.Errorf("expected %q, got %q (%v)", ContentTypePDF, contentType, err)
+		}
+
+		// The content type follows the file, as its digest does.
+		if err = os.WriteFile(fpath, []byte("<html>Foo</html>"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		contentType, err = ContentTypeOf(doc)
+		if err != nil || contentType != ContentTypeHTML {
+			t.Errorf("expected %q, got %q (%v)", ContentTypeHTML, contentType, err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error response body is read into GotenbergError.Message.
+const maxErrorBodySize = 64 << 10
+
+// GotenbergError is returned when the Gotenberg API responds with a non-successful status code.
+// Use errors.As to access it.
+type GotenbergError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by Gotenberg in the response body.
+	Message string
+	// Trace is the value of the Gotenberg-Trace response header.
+	Trace string
+	// Endpoint is the Gotenberg route the request was sent to.
+	Endpoint string
+	// OutputFilename is the output filename requested via OutputFilename, if any.
+	OutputFilename string
+}
+
+func (e *GotenbergError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s: %s responded with %d", errGenerationFailed, e.Endpoint, e.StatusCode)
+
+	if e.Message != "" {
+		sb.WriteString(": ")
+		sb.WriteString(e.Message)
+	}
+
+	if e.Trace != "" {
+		fmt.Fprintf(&sb, " (trace: %s)", e.Trace)
+	}
+
+	return sb.String()
+}
+
+// Unwrap allows errors.Is(err, errGenerationFailed) checks to keep working.
+func (e *GotenbergError) Unwrap() error {
+	return errGenerationFailed
+}
+
+// IsBadRequest reports whether Gotenberg rejected the request form (400 Bad Request).
+func (e *GotenbergError) IsBadRequest() bool {
+	return e.StatusCode == http.StatusBadRequest
+}
+
+// IsConflict reports whether the conversion failed because of a Chromium check,
+// e.g., FailOnConsoleExceptions or FailOnHTTPStatusCodes (409 Conflict).
+func (e *GotenbergError) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+// IsTimeout reports whether the conversion did not finish in time (503 Service Unavailable or 504 Gateway Timeout).
+func (e *GotenbergError) IsTimeout() bool {
+	return e.StatusCode == http.StatusServiceUnavailable || e.StatusCode == http.StatusGatewayTimeout
+}
+
+// IsRetryable reports whether sending the same request again may succeed.
+func (e *GotenbergError) IsRetryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// newGotenbergError builds a GotenbergError from a non-successful response. It consumes the response body
+// but does not close it. The request may be nil for requests without a multipart form, e.g., health checks.
+func newGotenbergError(resp *http.Response, req Request, endpoint string) *GotenbergError {
+	gErr := &GotenbergError{
+		StatusCode: resp.StatusCode,
+		Message:    readErrorMessage(resp.Body),
+		Trace:      resp.Header.Get(string(headerTrace)),
+		Endpoint:   endpoint,
+	}
+
+	if req != nil {
+		gErr.OutputFilename = req.customHeaders()[headerOutputFilename]
+	}
+
+	return gErr
+}
+
+// readErrorMessage extracts the error message from a response body. Gotenberg replies with plain text,
+// but JSON bodies with a "message" or "error" key (e.g., from a reverse proxy) are understood as well.
+func readErrorMessage(body io.Reader) string {
+	data, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+	if err != nil && len(data) == 0 {
+		return ""
+	}
+
+	msg := strings.TrimSpace(string(data))
+
+	var payload struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	if strings.HasPrefix(msg, "{") && json.Unmarshal(data, &payload) == nil {
+		if payload.Message != "" {
+			return payload.Message
+		}
+
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,78 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestGotenbergError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Gotenberg-Trace", "testGotenbergError")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte("Chromium console exceptions\n"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, http.DefaultClient)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+	req.OutputFilename("foo.pdf")
+
+	err = c.Store(context.Background(), req, t.TempDir()+"/foo.pdf")
+	require.Error(t, err)
+	require.ErrorIs(t, err, errGenerationFailed)
+
+	var gErr *GotenbergError
+	require.ErrorAs(t, err, &gErr)
+	assert.Equal(t, http.StatusConflict, gErr.StatusCode)
+	assert.Equal(t, "Chromium console exceptions", gErr.Message)
+	assert.Equal(t, "testGotenbergError", gErr.Trace)
+	assert.Equal(t, endpointHTMLConvert, gErr.Endpoint)
+	assert.Equal(t, "foo.pdf", gErr.OutputFilename)
+	assert.True(t, gErr.IsConflict())
+	assert.False(t, gErr.IsRetryable())
+
+	err = c.StoreScreenshot(context.Background(), req, t.TempDir()+"/foo.png")
+	require.ErrorAs(t, err, &gErr)
+	assert.Equal(t, endpointHTMLScreenshot, gErr.Endpoint)
+}
+
+func TestGotenbergErrorPredicates(t *testing.T) {
+	tests := []struct {
+		status     int
+		badRequest bool
+		timeout    bool
+		retryable  bool
+	}{
+		{status: http.StatusBadRequest, badRequest: true},
+		{status: http.StatusServiceUnavailable, timeout: true, retryable: true},
+		{status: http.StatusTooManyRequests, retryable: true},
+		{status: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		gErr := &GotenbergError{StatusCode: tc.status}
+		assert.Equal(t, tc.badRequest, gErr.IsBadRequest(), tc.status)
+		assert.Equal(t, tc.timeout, gErr.IsTimeout(), tc.status)
+		assert.Equal(t, tc.retryable, gErr.IsRetryable(), tc.status)
+		assert.True(t, errors.Is(gErr, errGenerationFailed))
+	}
+}
+
+func TestReadErrorMessage(t *testing.T) {
+	assert.Equal(t, "boom", readErrorMessage(strings.NewReader(`{"message":"boom"}`)))
+	assert.Equal(t, "bad gateway", readErrorMessage(strings.NewReader(`{"error":"bad gateway"}`)))
+	assert.Equal(t, "Invalid form data", readErrorMessage(strings.NewReader("  Invalid form data \n")))
+}
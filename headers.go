@@ -9,6 +9,7 @@ const (
 
 const (
 	headerAuthorization httpHeader = "Authorization"
+	headerUserAgent     httpHeader = "User-Agent"
 )
 
 const (
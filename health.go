@@ -0,0 +1,79 @@
+package gotenberg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const endpointHealth = "/health"
+
+// Gotenberg module names, as reported by the health endpoint.
+const (
+	ModuleChromium    = "chromium"
+	ModuleLibreOffice = "libreoffice"
+)
+
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+// ModuleHealth is the health of a single Gotenberg module.
+type ModuleHealth struct {
+	Status    HealthStatus `json:"status"`
+	Timestamp time.Time    `json:"timestamp"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// HealthReport is the health of a Gotenberg instance, as returned by its /health endpoint.
+type HealthReport struct {
+	Status  HealthStatus            `json:"status"`
+	Details map[string]ModuleHealth `json:"details"`
+}
+
+// IsUp reports whether the Gotenberg instance and all its modules are up.
+func (hr *HealthReport) IsUp() bool {
+	return hr.Status == HealthUp
+}
+
+// Module returns the health of the given module, e.g., ModuleChromium. The second result is false
+// if the module is not reported, i.e., it is disabled.
+func (hr *HealthReport) Module(name string) (ModuleHealth, bool) {
+	mh, ok := hr.Details[name]
+
+	return mh, ok
+}
+
+// Health checks the health of the Gotenberg instance. A report with a down status is not an error:
+// an error is returned only if the instance could not be reached or its response could not be decoded.
+func (c *Client) Health(ctx context.Context) (*HealthReport, error) {
+	req, err := c.createGetRequest(ctx, endpointHealth)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doHTTP(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Gotenberg responds with 503 Service Unavailable and a regular report if a module is down.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, newGotenbergError(resp, nil, endpointHealth)
+	}
+
+	report := &HealthReport{}
+	if err = json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("decoding health report: %w", err)
+	}
+
+	return report, nil
+}
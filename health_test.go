@@ -0,0 +1,65 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != endpointHealth {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{
+			"status": "down",
+			"details": {
+				"chromium": {"status": "up", "timestamp": "2024-07-01T08:45:13.612245Z"},
+				"libreoffice": {"status": "down", "timestamp": "2024-07-01T08:45:13.612245Z", "error": "LibreOffice is unhealthy"}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	report, err := c.Health(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.IsUp())
+
+	chromium, ok := report.Module(ModuleChromium)
+	require.True(t, ok)
+	assert.Equal(t, HealthUp, chromium.Status)
+	assert.Equal(t, 2024, chromium.Timestamp.Year())
+
+	libreOffice, ok := report.Module(ModuleLibreOffice)
+	require.True(t, ok)
+	assert.Equal(t, HealthDown, libreOffice.Status)
+	assert.Equal(t, "LibreOffice is unhealthy", libreOffice.Error)
+}
+
+func TestHealthUnexpectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Health(context.Background())
+
+	var gErr *GotenbergError
+	require.ErrorAs(t, err, &gErr)
+	assert.Equal(t, http.StatusUnauthorized, gErr.StatusCode)
+	assert.Equal(t, endpointHealth, gErr.Endpoint)
+}
@@ -0,0 +1,118 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var errInvalidHostname = errors.New("invalid hostname")
+
+// parseHostname parses the hostname given to NewClient, e.g., http://localhost:3000 or
+// https://gw.internal/gotenberg/?token=secret. A hostname without a scheme defaults to http.
+func parseHostname(hostname string) (*url.URL, error) {
+	if !strings.Contains(hostname, "://") {
+		hostname = "http://" + hostname
+	}
+
+	u, err := url.Parse(hostname)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidHostname, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w %q: unsupported scheme %q", errInvalidHostname, hostname, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w %q: missing host", errInvalidHostname, hostname)
+	}
+
+	if u.Fragment != "" {
+		return nil, fmt.Errorf("%w %q: unexpected fragment", errInvalidHostname, hostname)
+	}
+
+	// Normalize the path prefix so that endpoints can be appended to it: no trailing slash, no duplicate slashes.
+	u.Path = cleanPathPrefix(u.Path)
+	u.RawPath = ""
+
+	return u, nil
+}
+
+func cleanPathPrefix(p string) string {
+	segments := strings.Split(p, "/")
+
+	kept := segments[:0]
+	for _, segment := range segments {
+		if segment != "" {
+			kept = append(kept, segment)
+		}
+	}
+
+	if len(kept) == 0 {
+		return ""
+	}
+
+	return "/" + strings.Join(kept, "/")
+}
+
+// WithQueryParams sets query parameters appended to every endpoint, e.g., a token required by a reverse proxy.
+// They are merged with the query parameters of the hostname, if any.
+func WithQueryParams(params url.Values) ClientOption {
+	return func(c *Client) error {
+		query := c.baseURL.Query()
+		for key, values := range params {
+			query[key] = append([]string(nil), values...)
+		}
+
+		c.baseURL.RawQuery = query.Encode()
+
+		return nil
+	}
+}
+
+// doHTTP sends the request with the HTTP client. The URL of the returned *url.Error is redacted, so that
+// query parameters, e.g., tokens set with WithQueryParams, do not leak into errors and logs.
+func (c *Client) doHTTP(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+
+	return resp, err //nolint:wrapcheck // the *url.Error is returned as is.
+}
+
+// redactURL replaces the password and the query parameter values of the URL with "xxxxx", like url.URL.Redacted.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key, values := range query {
+			for i := range values {
+				values[i] = "xxxxx"
+			}
+
+			query[key] = values
+		}
+
+		u.RawQuery = query.Encode()
+	}
+
+	return u.Redacted()
+}
+
+// endpointURL returns the URL of the given Gotenberg route, such as /forms/chromium/convert/html.
+func (c *Client) endpointURL(endpoint string) string {
+	u := *c.baseURL
+	u.Path += endpoint
+
+	return u.String()
+}
@@ -1,9 +1,19 @@
 package gotenberg
 
 import (
+	"errors"
+	"fmt"
+	"path"
+
 	"github.com/starwalkn/gotenberg-go-client/v8/document"
 )
 
+var (
+	errMissingIndex  = errors.New("missing index.html")
+	errDuplicateName = errors.New("files with the same base name")
+	errReservedName  = errors.New("asset named after a reserved file")
+)
+
 const (
 	endpointHTMLConvert    = "/forms/chromium/convert/html"
 	endpointHTMLScreenshot = "/forms/chromium/screenshot/html"
@@ -27,6 +37,66 @@ func NewHTMLRequest(index document.Document) *HTMLRequest {
 	}
 }
 
+// NewHTMLRequestFromDir creates an HTMLRequest from the files of a directory, loaded with document.FromDir.
+// The index.html, header.html and footer.html files at the root of the directory are used as such,
+// every other file is an asset.
+//
+// Gotenberg puts all the files in the same directory, so the HTML must reference assets by their base name.
+// An error is returned if two files have the same base name, e.g., img/index.html and index.html, or if a file
+// of a subdirectory is named index.html, header.html or footer.html.
+func NewHTMLRequestFromDir(dir string, opts ...document.DirOption) (*HTMLRequest, error) {
+	docs, err := document.FromDir(dir, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading documents: %w", err)
+	}
+
+	var index, header, footer document.Document
+
+	assets := make([]document.Document, 0, len(docs))
+	names := make(map[string]string, len(docs))
+
+	for _, doc := range docs {
+		base := path.Base(doc.Filename())
+		if other, ok := names[base]; ok {
+			return nil, fmt.Errorf("%w: %s and %s", errDuplicateName, other, doc.Filename())
+		}
+
+		names[base] = doc.Filename()
+
+		if base != doc.Filename() && (base == "index.html" || base == "header.html" || base == "footer.html") {
+			return nil, fmt.Errorf("%w: %s", errReservedName, doc.Filename())
+		}
+
+		switch doc.Filename() {
+		case "index.html":
+			index = doc
+		case "header.html":
+			header = doc
+		case "footer.html":
+			footer = doc
+		default:
+			assets = append(assets, doc)
+		}
+	}
+
+	if index == nil {
+		return nil, fmt.Errorf("%w in %s", errMissingIndex, dir)
+	}
+
+	req := NewHTMLRequest(index)
+	req.Assets(assets...)
+
+	if header != nil {
+		req.Header(header)
+	}
+
+	if footer != nil {
+		req.Footer(footer)
+	}
+
+	return req, nil
+}
+
 func (req *HTMLRequest) endpoint() string {
 	return endpointHTMLConvert
 }
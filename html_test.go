@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -195,3 +197,48 @@ func TestHTMLEmbeds(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, hasEmbeds)
 }
+
+func TestNewHTMLRequestFromDir(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"index.html", "header.html", "footer.html", "style.css", "img/logo.png", "notes.md"} {
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fpath), 0o755))
+		require.NoError(t, os.WriteFile(fpath, []byte(name), 0o600))
+	}
+
+	req, err := NewHTMLRequestFromDir(dir, document.WithExclude("*.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "index.html", req.index.Filename())
+	assert.Equal(t, "header.html", req.header.Filename())
+	assert.Equal(t, "footer.html", req.footer.Filename())
+
+	files := make([]string, 0)
+	for fname := range req.formDocuments() {
+		files = append(files, fname)
+	}
+
+	assert.ElementsMatch(t, []string{"index.html", "header.html", "footer.html", "img/logo.png", "style.css"}, files)
+
+	_, err = NewHTMLRequestFromDir(dir, document.WithExclude("index.html"))
+	require.ErrorIs(t, err, errMissingIndex)
+
+	// Gotenberg only keeps base names, so these files would overwrite each other.
+	for _, name := range []string{"img/header.html", "css/style.css"} {
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fpath), 0o755))
+		require.NoError(t, os.WriteFile(fpath, []byte(name), 0o600))
+	}
+
+	_, err = NewHTMLRequestFromDir(dir, document.WithInclude("*.html"))
+	require.ErrorIs(t, err, errDuplicateName)
+	assert.Contains(t, err.Error(), "header.html and img/header.html")
+
+	_, err = NewHTMLRequestFromDir(dir, document.WithExclude("*.html"), document.WithInclude("index.html", "*.css"))
+	require.ErrorIs(t, err, errDuplicateName)
+	assert.Contains(t, err.Error(), "css/style.css and style.css")
+
+	_, err = NewHTMLRequestFromDir(dir, document.WithInclude("index.html", "img/*"))
+	require.ErrorIs(t, err, errReservedName)
+	assert.Contains(t, err.Error(), "img/header.html")
+}
@@ -0,0 +1,69 @@
+package gotenberg
+
+import (
+	"fmt"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+// DocumentInfo describes a document of a request, as returned by Inspect.
+type DocumentInfo struct {
+	// Field is the form field the document is sent in: "files", or "embeds" for files embedded in the resulting PDF.
+	Field string
+	// Name is the filename sent to Gotenberg.
+	Name string
+	// Size is the size in bytes, or -1 if it cannot be known without consuming the document.
+	Size int64
+	// SHA256 is the hex-encoded SHA-256 digest, or empty if it cannot be computed without consuming the document.
+	SHA256 string
+	// ContentType is the detected content type, or empty if it is unknown.
+	ContentType string
+}
+
+// Inspect returns information about the documents of the request, sorted by field and name, e.g., for logging,
+// cache keys or upload size limits. Documents which implement neither document.Sizer nor document.Digester are read.
+func Inspect(req Request) ([]DocumentInfo, error) {
+	var infos []DocumentInfo
+
+	for _, part := range []struct {
+		field     string
+		documents map[string]document.Document
+	}{
+		{formFilesFieldname, req.formDocuments()},
+		{formEmbedsFieldname, req.formEmbeds()},
+	} {
+		for _, name := range sortedKeys(part.documents) {
+			info, err := inspectDocument(part.field, name, part.documents[name])
+			if err != nil {
+				return nil, err
+			}
+
+			infos = append(infos, info)
+		}
+	}
+
+	return infos, nil
+}
+
+func inspectDocument(field, name string, doc document.Document) (DocumentInfo, error) {
+	size, err := document.SizeOf(doc)
+	if err != nil {
+		return DocumentInfo{}, fmt.Errorf("inspecting %s: %w", name, err)
+	}
+
+	digest, err := document.DigestOf(doc)
+	if err != nil {
+		return DocumentInfo{}, fmt.Errorf("inspecting %s: %w", name, err)
+	}
+
+	// Like validation, inspection tolerates documents whose content type cannot be detected.
+	contentType, _ := document.ContentTypeOf(doc)
+
+	return DocumentInfo{
+		Field:       field,
+		Name:        name,
+		Size:        size,
+		SHA256:      digest,
+		ContentType: contentType,
+	}, nil
+}
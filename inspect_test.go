@@ -0,0 +1,44 @@
+package gotenberg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestInspect(t *testing.T) {
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	style, err := document.FromReader("style.css", strings.NewReader("body {}"))
+	require.NoError(t, err)
+	invoice, err := document.FromBytes("invoice.xml", []byte("<invoice/>"))
+	require.NoError(t, err)
+
+	req := NewHTMLRequest(index)
+	req.Assets(style)
+	req.Embeds(invoice)
+
+	infos, err := Inspect(req)
+	require.NoError(t, err)
+	assert.Equal(t, []DocumentInfo{
+		{
+			Field:       "files",
+			Name:        "index.html",
+			Size:        16,
+			SHA256:      "456eeab751cebdff99504f019076e3e3ccabf966d2f22dcba10159c70b1d1191",
+			ContentType: "text/html",
+		},
+		{Field: "files", Name: "style.css", Size: -1, ContentType: "text/plain"},
+		{
+			Field:       "embeds",
+			Name:        "invoice.xml",
+			Size:        10,
+			SHA256:      "61bd5d958720d3c3b0854d997ec0b62b050d3827091ccf54aaf2e6d641530c28",
+			ContentType: "text/plain",
+		},
+	}, infos)
+}
@@ -0,0 +1,181 @@
+package gotenberg
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Engine is the Gotenberg module which processes a request, derived from its route.
+type Engine string
+
+const (
+	EngineChromium    Engine = "chromium"
+	EngineLibreOffice Engine = "libreoffice"
+	EnginePDFEngines  Engine = "pdfengines"
+)
+
+// engineOf returns the engine of a route such as /forms/chromium/convert/html.
+func engineOf(endpoint string) Engine {
+	rest, ok := strings.CutPrefix(endpoint, "/forms/")
+	if !ok {
+		return ""
+	}
+
+	engine, _, _ := strings.Cut(rest, "/")
+
+	return Engine(engine)
+}
+
+// WithConcurrencyLimit limits the number of requests in flight to n. Additional requests are queued
+// in FIFO order until a slot is free or their context is done.
+func WithConcurrencyLimit(n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid concurrency limit %d", n)
+		}
+
+		c.limiter = newLimiter(n)
+
+		return nil
+	}
+}
+
+// WithEngineConcurrencyLimit limits the number of requests in flight processed by the given engine to n,
+// e.g., to the number of Chromium workers of the Gotenberg instance. It can be combined with WithConcurrencyLimit.
+func WithEngineConcurrencyLimit(engine Engine, n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid %s concurrency limit %d", engine, n)
+		}
+
+		if c.engineLimiters == nil {
+			c.engineLimiters = make(map[Engine]*limiter)
+		}
+
+		c.engineLimiters[engine] = newLimiter(n)
+
+		return nil
+	}
+}
+
+// QueueDepth returns the number of requests waiting for a free slot, across all concurrency limits.
+func (c *Client) QueueDepth() int {
+	depth := c.limiter.queued()
+	for _, l := range c.engineLimiters {
+		depth += l.queued()
+	}
+
+	return depth
+}
+
+// EngineQueueDepth returns the number of requests waiting for a free slot of the given engine limit.
+func (c *Client) EngineQueueDepth(engine Engine) int {
+	return c.engineLimiters[engine].queued()
+}
+
+// acquireSlot waits for a free slot for a request to the given endpoint. The returned function releases it.
+// The engine slot is acquired first so that a request waiting for its engine does not hold a global slot.
+func (c *Client) acquireSlot(ctx context.Context, endpoint string) (func(), error) {
+	engineLimiter := c.engineLimiters[engineOf(endpoint)]
+
+	if err := engineLimiter.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for a free %s slot: %w", engineOf(endpoint), err)
+	}
+
+	if err := c.limiter.acquire(ctx); err != nil {
+		engineLimiter.release()
+
+		return nil, fmt.Errorf("waiting for a free slot: %w", err)
+	}
+
+	return func() {
+		c.limiter.release()
+		engineLimiter.release()
+	}, nil
+}
+
+// limiter is a counting semaphore which admits waiters in FIFO order. A nil limiter admits everything.
+type limiter struct {
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	waiters  list.List // of chan struct{}
+}
+
+func newLimiter(limit int) *limiter {
+	return &limiter{limit: limit}
+}
+
+func (l *limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	if l.inFlight < l.limit && l.waiters.Len() == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+
+		return nil
+	}
+
+	ready := make(chan struct{})
+	elem := l.waiters.PushBack(ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		select {
+		case <-ready:
+			// The slot was handed over concurrently: pass it on.
+			l.releaseLocked()
+		default:
+			l.waiters.Remove(elem)
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.releaseLocked()
+}
+
+// releaseLocked hands the slot over to the first waiter, if any.
+func (l *limiter) releaseLocked() {
+	if front := l.waiters.Front(); front != nil {
+		l.waiters.Remove(front)
+		close(front.Value.(chan struct{})) //nolint:forcetypeassert // the list only holds channels.
+
+		return
+	}
+
+	l.inFlight--
+}
+
+func (l *limiter) queued() int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waiters.Len()
+}
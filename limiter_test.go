@@ -0,0 +1,111 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestEngineOf(t *testing.T) {
+	assert.Equal(t, EngineChromium, engineOf(endpointHTMLConvert))
+	assert.Equal(t, EngineLibreOffice, engineOf(endpointOfficeConvert))
+	assert.Equal(t, EnginePDFEngines, engineOf(endpointMetadataRead))
+	assert.Equal(t, Engine(""), engineOf(endpointHealth))
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	unblock := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		<-unblock
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithConcurrencyLimit(4), WithEngineConcurrencyLimit(EngineChromium, 2))
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, sendErr := c.Send(context.Background(), NewHTMLRequest(index))
+			if assert.NoError(t, sendErr) {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return c.QueueDepth() == 3 && inFlight.Load() == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 3, c.EngineQueueDepth(EngineChromium))
+	assert.Equal(t, 0, c.EngineQueueDepth(EngineLibreOffice))
+
+	close(unblock)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxInFlight.Load())
+	assert.Equal(t, 0, c.QueueDepth())
+}
+
+func TestLimiterFIFO(t *testing.T) {
+	l := newLimiter(1)
+	require.NoError(t, l.acquire(context.Background()))
+
+	order := make(chan int, 3)
+	for i := range 3 {
+		go func() {
+			_ = l.acquire(context.Background())
+			order <- i
+			l.release()
+		}()
+
+		require.Eventually(t, func() bool { return l.queued() == i+1 }, time.Second, time.Millisecond)
+	}
+
+	l.release()
+
+	for i := range 3 {
+		assert.Equal(t, i, <-order)
+	}
+}
+
+func TestLimiterContextCanceled(t *testing.T) {
+	l := newLimiter(1)
+	require.NoError(t, l.acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, l.acquire(ctx), context.DeadlineExceeded)
+	assert.Equal(t, 0, l.queued())
+
+	l.release()
+	require.NoError(t, l.acquire(context.Background()))
+}
@@ -0,0 +1,175 @@
+package gotenberg
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+const redacted = "[REDACTED]"
+
+// Form fields whose values are never logged, as they contain passwords or credentials.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var redactedFormFields = map[formField]bool{
+	fieldUserPassword:             true,
+	fieldOwnerPassword:            true,
+	fieldOfficePassword:           true,
+	fieldChromiumCookies:          true,
+	fieldChromiumExtraHTTPHeaders: true,
+	fieldDownloadFrom:             true,
+}
+
+// HTTP headers whose values are never logged.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var redactedHeaders = map[httpHeader]bool{
+	headerAuthorization:       true,
+	headerWebhookExtraHeaders: true,
+}
+
+// WithLogger makes the client emit one structured record per conversion request, with the endpoint, trace,
+// output filename, documents, form fields, headers, duration and response status. Passwords, cookies
+// and credentials are redacted. Successful requests are logged at the Info level, failed ones at the Error level.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.logger = logger
+
+		return nil
+	}
+}
+
+func (c *Client) logConversion(
+	ctx context.Context,
+	mr MultipartRequest,
+	endpoint string,
+	duration time.Duration,
+	attempts int,
+	resp *http.Response,
+	err error,
+) {
+	if c.logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelError
+	}
+
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	trace := mr.customHeaders()[headerTrace]
+	if trace == "" {
+		trace = traceOf(ctx)
+	}
+
+	if trace == "" && resp != nil {
+		trace = resp.Header.Get(string(headerTrace))
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", endpoint),
+		slog.String("trace", trace),
+		slog.String("output_filename", mr.customHeaders()[headerOutputFilename]),
+		documentsAttr("documents", mr.formDocuments()),
+		documentsAttr("embeds", mr.formEmbeds()),
+		formFieldsAttr(mr.formFields()),
+		headersAttr(mr.customHeaders()),
+		slog.Duration("duration", duration),
+		slog.Int("attempts", attempts),
+	}
+
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+		if t := timerOf(resp); t != nil {
+			attrs = append(attrs, timingsAttr(t.timings()))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger.LogAttrs(ctx, level, "gotenberg request", attrs...)
+}
+
+// timingsAttr groups the timings measured until the response headers were received.
+func timingsAttr(t Timings) slog.Attr {
+	return slog.Group("timings",
+		slog.Duration("connect", t.Connect),
+		slog.Duration("tls", t.TLS),
+		slog.Duration("upload", t.Upload),
+		slog.Duration("ttfb", t.TTFB),
+		slog.Bool("conn_reused", t.ConnReused),
+	)
+}
+
+// documentsAttr groups the documents by name, with their size in bytes, or -1 if it is unknown.
+func documentsAttr(key string, docs map[string]document.Document) slog.Attr {
+	names := sortedKeys(docs)
+	attrs := make([]slog.Attr, 0, len(names))
+
+	for _, name := range names {
+		size := int64(-1)
+		if sizer, ok := docs[name].(document.Sizer); ok {
+			if n, err := sizer.Size(); err == nil {
+				size = n
+			}
+		}
+
+		attrs = append(attrs, slog.Int64(name, size))
+	}
+
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+func formFieldsAttr(fields map[formField]string) slog.Attr {
+	names := sortedKeys(fields)
+	attrs := make([]slog.Attr, 0, len(names))
+
+	for _, name := range names {
+		value := fields[name]
+		if redactedFormFields[name] {
+			value = redacted
+		}
+
+		attrs = append(attrs, slog.String(string(name), value))
+	}
+
+	return slog.Attr{Key: "fields", Value: slog.GroupValue(attrs...)}
+}
+
+func headersAttr(headers map[httpHeader]string) slog.Attr {
+	names := sortedKeys(headers)
+	attrs := make([]slog.Attr, 0, len(names))
+
+	for _, name := range names {
+		value := headers[name]
+		if redactedHeaders[name] {
+			value = redacted
+		}
+
+		attrs = append(attrs, slog.String(string(name), value))
+	}
+
+	return slog.Attr{Key: "headers", Value: slog.GroupValue(attrs...)}
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,92 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Gotenberg-Trace", "server-trace")
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+
+	c, err := NewClient(srv.URL, nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	require.NoError(t, err)
+
+	doc, err := document.FromString("document.docx", "docx")
+	require.NoError(t, err)
+	reader, err := document.FromReader("sheet.xlsx", strings.NewReader("xlsx"))
+	require.NoError(t, err)
+
+	req := NewLibreOfficeRequest(doc, reader)
+	req.Password("open-sesame")
+	req.Encrypt("user-secret", "owner-secret")
+	req.Landscape()
+	req.UseBasicAuth("foo", "bar")
+	req.OutputFilename("foo.pdf")
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	output := buf.String()
+	for _, secret := range []string{"open-sesame", "user-secret", "owner-secret", "Basic "} {
+		assert.NotContains(t, output, secret)
+	}
+
+	var record struct {
+		Level          string           `json:"level"`
+		Msg            string           `json:"msg"`
+		Endpoint       string           `json:"endpoint"`
+		Trace          string           `json:"trace"`
+		OutputFilename string           `json:"output_filename"`
+		Documents      map[string]int64 `json:"documents"`
+		Fields         map[string]string
+		Headers        map[string]string
+		Attempts       int `json:"attempts"`
+		Status         int `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "INFO", record.Level)
+	assert.Equal(t, endpointOfficeConvert, record.Endpoint)
+	assert.Equal(t, "server-trace", record.Trace)
+	assert.Equal(t, "foo.pdf", record.OutputFilename)
+	assert.Equal(t, map[string]int64{"document.docx": 4, "sheet.xlsx": -1}, record.Documents)
+	assert.Equal(t, redacted, record.Fields["password"])
+	assert.Equal(t, redacted, record.Fields["userPassword"])
+	assert.Equal(t, "true", record.Fields["landscape"])
+	assert.Equal(t, redacted, record.Headers["Authorization"])
+	assert.Equal(t, 1, record.Attempts)
+	assert.Equal(t, http.StatusOK, record.Status)
+}
+
+func TestLoggerError(t *testing.T) {
+	var buf bytes.Buffer
+
+	c, err := NewClient("http://127.0.0.1:1", nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewMergeRequest(pdf))
+	require.Error(t, err)
+
+	assert.Contains(t, buf.String(), `"level":"ERROR"`)
+	assert.Contains(t, buf.String(), errSendRequestFailed.Error())
+}
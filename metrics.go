@@ -0,0 +1,189 @@
+package gotenberg
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const endpointMetrics = "/prometheus/metrics"
+
+var errInvalidMetrics = errors.New("invalid Prometheus exposition")
+
+// Suffixes of the Gotenberg metric names, which are prefixed by the configurable Prometheus namespace
+// ("gotenberg" by default).
+const (
+	metricRequestsQueueSize = "_requests_queue_size"
+	metricRestartsCount     = "_restarts_count"
+	metricActiveInstances   = "_active_instances_count"
+)
+
+// EngineMetrics are the metrics of a Gotenberg module backed by a process pool.
+type EngineMetrics struct {
+	// RequestsQueueSize is the number of requests waiting for the engine.
+	RequestsQueueSize int
+	// ActiveInstances is the number of running engine instances.
+	ActiveInstances int
+	// Restarts is the number of engine restarts.
+	Restarts int
+}
+
+// Metrics is a snapshot of the metrics of a Gotenberg instance.
+type Metrics struct {
+	Chromium    EngineMetrics
+	LibreOffice EngineMetrics
+
+	// Samples holds every sample of the exposition, keyed by the metric name followed by its labels
+	// as they appear in the exposition, e.g., `gotenberg_chromium_requests_queue_size` or `go_info{version="go1.24"}`.
+	Samples map[string]float64
+}
+
+// Engine returns the metrics of the given engine. The second result is false for engines without
+// a process pool, i.e., EnginePDFEngines.
+func (m *Metrics) Engine(engine Engine) (EngineMetrics, bool) {
+	switch engine {
+	case EngineChromium:
+		return m.Chromium, true
+	case EngineLibreOffice:
+		return m.LibreOffice, true
+	default:
+		return EngineMetrics{}, false
+	}
+}
+
+// Metrics fetches and parses the metrics of the Gotenberg instance, as returned by its /prometheus/metrics endpoint.
+func (c *Client) Metrics(ctx context.Context) (*Metrics, error) {
+	req, err := c.createGetRequest(ctx, endpointMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doHTTP(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newGotenbergError(resp, nil, endpointMetrics)
+	}
+
+	return ParseMetrics(resp.Body)
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format.
+func ParseMetrics(r io.Reader) (*Metrics, error) {
+	m := &Metrics{Samples: make(map[string]float64)}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, labels, value, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", errInvalidMetrics, lineNumber, err)
+		}
+
+		m.Samples[name+labels] = value
+		m.setEngineMetric(name, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading metrics: %w", err)
+	}
+
+	return m, nil
+}
+
+// setEngineMetric sets the engine metric matching the sample name, if any.
+func (m *Metrics) setEngineMetric(name string, value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+
+	var metrics *EngineMetrics
+
+	switch {
+	case strings.Contains(name, string(EngineChromium)+"_"):
+		metrics = &m.Chromium
+	case strings.Contains(name, string(EngineLibreOffice)+"_"):
+		metrics = &m.LibreOffice
+	default:
+		return
+	}
+
+	n := int(math.Round(value))
+
+	switch name = strings.TrimSuffix(name, "_total"); {
+	case strings.HasSuffix(name, metricRequestsQueueSize):
+		metrics.RequestsQueueSize = n
+	case strings.HasSuffix(name, metricActiveInstances):
+		metrics.ActiveInstances = n
+	case strings.HasSuffix(name, metricRestartsCount):
+		metrics.Restarts = n
+	}
+}
+
+// parseSample parses a sample line such as `name{label="value"} 1 1700000000000`. The optional timestamp is ignored.
+func parseSample(line string) (name, labels string, value float64, err error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return "", "", 0, fmt.Errorf("missing value in %q", line)
+	}
+
+	name, rest := line[:end], line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		closing := labelsEnd(rest)
+		if closing < 0 {
+			return "", "", 0, fmt.Errorf("unterminated labels in %q", line)
+		}
+
+		labels, rest = rest[:closing+1], rest[closing+1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", "", 0, fmt.Errorf("invalid value in %q", line)
+	}
+
+	value, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid value in %q: %w", line, err)
+	}
+
+	return name, labels, value, nil
+}
+
+// labelsEnd returns the index of the brace closing the labels at the beginning of s, skipping quoted label values.
+func labelsEnd(s string) int {
+	inQuotes := false
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if inQuotes {
+				i++
+			}
+		case '"':
+			inQuotes = !inQuotes
+		case '}':
+			if !inQuotes {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
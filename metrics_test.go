@@ -0,0 +1,70 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP gotenberg_chromium_requests_queue_size Current number of Chromium conversion requests waiting to be treated.
+# TYPE gotenberg_chromium_requests_queue_size gauge
+gotenberg_chromium_requests_queue_size 3
+# HELP gotenberg_chromium_restarts_count Current number of Chromium restarts.
+# TYPE gotenberg_chromium_restarts_count gauge
+gotenberg_chromium_restarts_count 1
+# HELP gotenberg_chromium_active_instances_count Current number of active Chromium instances.
+# TYPE gotenberg_chromium_active_instances_count gauge
+gotenberg_chromium_active_instances_count 2
+# HELP gotenberg_libreoffice_requests_queue_size Current number of LibreOffice conversion requests waiting to be treated.
+# TYPE gotenberg_libreoffice_requests_queue_size gauge
+gotenberg_libreoffice_requests_queue_size 7
+gotenberg_libreoffice_restarts_count 0
+gotenberg_libreoffice_active_instances_count 1 1700000000000
+go_info{version="go1.24 {beta}",label="a \"quoted\" value"} 1
+process_cpu_seconds_total NaN
+`
+
+func TestParseMetrics(t *testing.T) {
+	m, err := ParseMetrics(strings.NewReader(testMetrics))
+	require.NoError(t, err)
+
+	assert.Equal(t, EngineMetrics{RequestsQueueSize: 3, ActiveInstances: 2, Restarts: 1}, m.Chromium)
+	assert.Equal(t, EngineMetrics{RequestsQueueSize: 7, ActiveInstances: 1, Restarts: 0}, m.LibreOffice)
+	assert.InDelta(t, 1, m.Samples[`go_info{version="go1.24 {beta}",label="a \"quoted\" value"}`], 0)
+	assert.Contains(t, m.Samples, "process_cpu_seconds_total")
+
+	libreOffice, ok := m.Engine(EngineLibreOffice)
+	assert.True(t, ok)
+	assert.Equal(t, 7, libreOffice.RequestsQueueSize)
+	_, ok = m.Engine(EnginePDFEngines)
+	assert.False(t, ok)
+
+	for _, invalid := range []string{"gotenberg_chromium_restarts_count", `go_info{version="go1.24" 1`, "up one"} {
+		_, err = ParseMetrics(strings.NewReader(invalid))
+		require.ErrorIs(t, err, errInvalidMetrics, invalid)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != endpointMetrics {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	m, err := c.Metrics(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.Chromium.RequestsQueueSize)
+}
@@ -0,0 +1,47 @@
+package gotenberg
+
+import "net/http"
+
+// Call is a single attempt to send a request to the Gotenberg API, as seen by middlewares.
+type Call struct {
+	// Request is the request being sent.
+	Request MultipartRequest
+	// Endpoint is the Gotenberg route the request is sent to.
+	Endpoint string
+	// HTTPRequest is the HTTP request built from Request. Middlewares may modify it, e.g., to set headers.
+	HTTPRequest *http.Request
+
+	timer *timer
+}
+
+// Handler executes a call and returns the Gotenberg API response.
+type Handler func(call *Call) (*http.Response, error)
+
+// Middleware wraps a Handler to add cross-cutting behavior such as logging, metrics or fault injection.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middlewares around the execution of conversion requests. The first middleware is
+// the outermost. Each retry attempt goes through the whole chain.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.addMiddlewares(middlewares...)
+
+		return nil
+	}
+}
+
+func (c *Client) addMiddlewares(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+
+	handler := c.roundTrip
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+
+	c.handler = handler
+}
+
+// roundTrip is the innermost handler, which actually sends the HTTP request.
+func (c *Client) roundTrip(call *Call) (*http.Response, error) {
+	return c.doHTTP(call.HTTPRequest)
+}
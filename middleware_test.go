@@ -0,0 +1,167 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestMiddleware(t *testing.T) {
+	var received http.Header
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	var calls []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(call *Call) (*http.Response, error) {
+				calls = append(calls, name+" "+call.Endpoint)
+
+				resp, err := next(call)
+				if err == nil {
+					calls = append(calls, name+" "+resp.Status)
+				}
+
+				return resp, err
+			}
+		}
+	}
+
+	c, err := NewClient(srv.URL, nil,
+		WithMiddleware(record("outer"), record("inner")),
+		WithMiddleware(func(next Handler) Handler {
+			return func(call *Call) (*http.Response, error) {
+				call.HTTPRequest.Header.Set("X-Refreshed-Token", "token")
+
+				return next(call)
+			}
+		}),
+	)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	resp, err := c.Screenshot(context.Background(), NewHTMLRequest(index))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{
+		"outer " + endpointHTMLScreenshot,
+		"inner " + endpointHTMLScreenshot,
+		"inner 201 Created",
+		"outer 201 Created",
+	}, calls)
+	assert.Equal(t, "token", received.Get("X-Refreshed-Token"))
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	errInjected := errors.New("injected fault")
+
+	c, err := NewClient("http://localhost:3000", nil, WithMiddleware(func(Handler) Handler {
+		return func(*Call) (*http.Response, error) {
+			return nil, errInjected
+		}
+	}))
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewMergeRequest(pdf))
+	require.ErrorIs(t, err, errSendRequestFailed)
+	require.ErrorIs(t, err, errInjected)
+}
+
+func TestMiddlewareFaultInjectionStreaming(t *testing.T) {
+	errInjected := errors.New("injected fault")
+
+	c, err := NewClient("http://localhost:3000", nil, WithStreaming(), WithMiddleware(func(Handler) Handler {
+		return func(call *Call) (*http.Response, error) {
+			if call.HTTPRequest.Header.Get("Gotenberg-Trace") == "fault" {
+				return nil, errInjected
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("%PDF-")),
+				Request:    call.HTTPRequest,
+			}, nil
+		}
+	}))
+	require.NoError(t, err)
+
+	t.Run("Error", func(t *testing.T) {
+		pdf := newClosingDocument("gotenberg.pdf")
+		req := NewMergeRequest(pdf)
+		req.Trace("fault")
+
+		_, err = c.Send(context.Background(), req)
+		require.ErrorIs(t, err, errInjected)
+		pdf.assertClosed(t)
+	})
+
+	t.Run("Response", func(t *testing.T) {
+		pdf := newClosingDocument("gotenberg.pdf")
+
+		resp, err := c.Send(context.Background(), NewMergeRequest(pdf))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		pdf.assertClosed(t)
+	})
+}
+
+// closingDocument reports when its reader is closed, i.e., when the multipart writer is done with it.
+type closingDocument struct {
+	name   string
+	closed chan struct{}
+}
+
+func newClosingDocument(name string) *closingDocument {
+	return &closingDocument{name: name, closed: make(chan struct{})}
+}
+
+func (doc *closingDocument) Filename() string {
+	return doc.name
+}
+
+func (doc *closingDocument) Reader() (io.ReadCloser, error) {
+	return &closingReader{Reader: strings.NewReader("%PDF-"), closed: doc.closed}, nil
+}
+
+func (doc *closingDocument) assertClosed(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-doc.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("document reader was never closed")
+	}
+}
+
+type closingReader struct {
+	io.Reader
+
+	closed chan struct{}
+}
+
+func (r *closingReader) Close() error {
+	close(r.closed)
+
+	return nil
+}
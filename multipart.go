@@ -2,6 +2,7 @@ package gotenberg
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -9,7 +10,15 @@ import (
 	"github.com/starwalkn/gotenberg-go-client/v8/document"
 )
 
-func multipartForm(mr MultipartRequest) (body *bytes.Buffer, contentType string, err error) {
+// errReadDocument tags the errors of the documents, which are not transient, from those of the multipart writer.
+var errReadDocument = errors.New("document reading failed")
+
+const (
+	formFilesFieldname  = "files"
+	formEmbedsFieldname = "embeds"
+)
+
+func multipartForm(mr MultipartRequest, progress ProgressFunc) (body *bytes.Buffer, contentType string, err error) {
 	body = &bytes.Buffer{}
 
 	writer := multipart.NewWriter(body)
@@ -19,19 +28,94 @@ func multipartForm(mr MultipartRequest) (body *bytes.Buffer, contentType string,
 		}
 	}()
 
-	if err = addDocuments(writer, mr.formDocuments(), "files"); err != nil {
+	if err = writeMultipartForm(writer, mr, progress); err != nil {
 		return nil, "", err
 	}
 
-	if err = addDocuments(writer, mr.formEmbeds(), "embeds"); err != nil {
-		return nil, "", err
+	return body, writer.FormDataContentType(), nil
+}
+
+// streamMultipartForm returns a body which is filled by a separate goroutine while the request is being sent,
+// so the documents are never held in memory as a whole. A document reading error is propagated to the reader
+// of the body, i.e., to the HTTP request. Closing the body (which the HTTP transport does when the request
+// context is canceled) stops the goroutine.
+//
+// contentLength is -1 unless the sizes of all documents are known in advance.
+func streamMultipartForm(mr MultipartRequest, progress ProgressFunc) (body io.ReadCloser, contentType string, contentLength int64) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	contentLength = multipartContentLength(mr, writer.Boundary())
+
+	go func() {
+		err := writeMultipartForm(writer, mr, progress)
+		if err == nil {
+			if err = writer.Close(); err != nil {
+				err = fmt.Errorf("error closing writer: %w", err)
+			}
+		}
+
+		// A nil error closes the pipe with io.EOF.
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr, writer.FormDataContentType(), contentLength
+}
+
+func writeMultipartForm(writer *multipart.Writer, mr MultipartRequest, progress ProgressFunc) error {
+	if err := addDocuments(writer, mr.formDocuments(), formFilesFieldname, progress); err != nil {
+		return err
 	}
 
-	if err = addFormFields(writer, mr.formFields()); err != nil {
-		return nil, "", err
+	if err := addDocuments(writer, mr.formEmbeds(), formEmbedsFieldname, progress); err != nil {
+		return err
 	}
 
-	return body, writer.FormDataContentType(), nil
+	return addFormFields(writer, mr.formFields())
+}
+
+// multipartContentLength computes the exact size of the multipart body written with the given boundary.
+// It returns -1 if at least one document does not implement document.Sizer or its size cannot be determined.
+func multipartContentLength(mr MultipartRequest, boundary string) int64 {
+	counter := &countingWriter{}
+
+	writer := multipart.NewWriter(counter)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return -1
+	}
+
+	var docsSize int64
+
+	for _, part := range []struct {
+		fieldname string
+		documents map[string]document.Document
+	}{
+		{formFilesFieldname, mr.formDocuments()},
+		{formEmbedsFieldname, mr.formEmbeds()},
+	} {
+		for fname, doc := range part.documents {
+			size := documentSize(doc)
+			if size < 0 {
+				return -1
+			}
+
+			if _, err := writer.CreateFormFile(part.fieldname, fname); err != nil {
+				return -1
+			}
+
+			docsSize += size
+		}
+	}
+
+	if err := addFormFields(writer, mr.formFields()); err != nil {
+		return -1
+	}
+
+	if err := writer.Close(); err != nil {
+		return -1
+	}
+
+	return counter.n + docsSize
 }
 
 func addFormFields(writer *multipart.Writer, formFields map[formField]string) error {
@@ -44,11 +128,16 @@ func addFormFields(writer *multipart.Writer, formFields map[formField]string) er
 	return nil
 }
 
-func addDocuments(writer *multipart.Writer, documents map[string]document.Document, fieldname string) error {
+func addDocuments(writer *multipart.Writer, documents map[string]document.Document, fieldname string, progress ProgressFunc) error {
 	for fname, doc := range documents {
 		in, err := doc.Reader()
 		if err != nil {
-			return fmt.Errorf("getting %s reader: %w", fname, err)
+			return fmt.Errorf("%w: getting %s reader: %w", errReadDocument, fname, err)
+		}
+
+		var src io.Reader = &documentReader{r: in}
+		if progress != nil {
+			src = newProgressReader(src, progress, ProgressUpload, fname, documentSize(doc))
 		}
 
 		part, err := writer.CreateFormFile(fieldname, fname)
@@ -58,12 +147,56 @@ func addDocuments(writer *multipart.Writer, documents map[string]document.Docume
 			return fmt.Errorf("creating %s form file: %w", fname, err)
 		}
 
-		if _, err = io.Copy(part, in); err != nil {
+		if _, err = io.Copy(part, src); err != nil {
 			_ = in.Close()
 
 			return fmt.Errorf("copying %s data: %w", fname, err)
 		}
+
+		if err = in.Close(); err != nil {
+			return fmt.Errorf("closing %s reader: %w", fname, err)
+		}
 	}
 
 	return nil
 }
+
+// documentReader tags the read errors of a document with errReadDocument. When streaming, they reach the caller
+// through the HTTP transport, wrapped in errSendRequestFailed.
+type documentReader struct {
+	r io.Reader
+}
+
+func (dr *documentReader) Read(p []byte) (int, error) {
+	n, err := dr.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("%w: %w", errReadDocument, err)
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
+
+// documentSize returns the size of the document, or -1 if it is unknown.
+func documentSize(doc document.Document) int64 {
+	sizer, ok := doc.(document.Sizer)
+	if !ok {
+		return -1
+	}
+
+	size, err := sizer.Size()
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+
+	return len(p), nil
+}
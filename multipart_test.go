@@ -0,0 +1,103 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestStreamingRequest(t *testing.T) {
+	var (
+		contentLength int64
+		files         = make(map[string]string)
+		fields        = make(map[string]string)
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for name, values := range r.MultipartForm.Value {
+			fields[name] = values[0]
+		}
+
+		for _, fh := range r.MultipartForm.File["files"] {
+			f, _ := fh.Open()
+			data, _ := io.ReadAll(f)
+			files[fh.Filename] = string(data)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithStreaming())
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	style, err := document.FromBytes("style.css", []byte("body { color: red; }"))
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+	req.Assets(style)
+	req.Scale(1.5)
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _, err := multipartForm(req, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(body.Len()), contentLength)
+	assert.Equal(t, map[string]string{"index.html": "<html>Foo</html>", "style.css": "body { color: red; }"}, files)
+	assert.Equal(t, "1.500000", fields["scale"])
+
+	// The size of a reader is unknown, so the body is sent chunked.
+	reader, err := document.FromReader("script.js", strings.NewReader("alert(1);"))
+	require.NoError(t, err)
+	req.Assets(reader)
+
+	resp, err = c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, int64(-1), contentLength)
+	assert.Equal(t, "alert(1);", files["script.js"])
+}
+
+func TestStreamingRequestReadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithStreaming())
+	require.NoError(t, err)
+
+	errRead := errors.New("read failure")
+	doc, err := document.FromReader("broken.pdf", io.MultiReader(strings.NewReader("%PDF-"), &failingReader{err: errRead}))
+	require.NoError(t, err)
+
+	_, err = c.Send(context.Background(), NewMergeRequest(doc))
+	require.ErrorIs(t, err, errSendRequestFailed)
+	require.ErrorIs(t, err, errRead)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
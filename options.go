@@ -0,0 +1,116 @@
+package gotenberg
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a Client created with NewClient.
+type ClientOption func(c *Client) error
+
+// WithBasicAuth sets the basic authentication credentials sent with every request.
+// Credentials set on a request with UseBasicAuth take precedence.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) error {
+		auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		c.defaultHeaders[headerAuthorization] = "Basic " + auth
+
+		return nil
+	}
+}
+
+// WithDefaultHeaders sets HTTP headers sent with every request, e.g., headers required by a reverse proxy.
+// Headers set on a request take precedence. See WithTracePrefix for a distinct Gotenberg-Trace per request.
+func WithDefaultHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) error {
+		for key, value := range headers {
+			c.defaultHeaders[httpHeader(http.CanonicalHeaderKey(key))] = value
+		}
+
+		return nil
+	}
+}
+
+// WithUserAgent overrides the User-Agent header of the requests sent to the Gotenberg API.
+//
+// NOTE: this is not the User-Agent used by Chromium to load pages; see chromiumRequest.UserAgent for that.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) error {
+		c.defaultHeaders[headerUserAgent] = ua
+
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for requests, including reading the response body.
+// The given http.Client is not modified; the client works with a copy.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.httpClient = cloneHTTPClient(c.httpClient)
+		c.httpClient.Timeout = timeout
+
+		return nil
+	}
+}
+
+// WithTransport sets the transport used to send requests.
+// The given http.Client is not modified; the client works with a copy.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		c.httpClient = cloneHTTPClient(c.httpClient)
+		c.httpClient.Transport = transport
+
+		return nil
+	}
+}
+
+// WithDefaultWebhook sets the callback and error callback used for every request.
+// A webhook set on a request with UseWebhook takes precedence.
+//
+// NOTE: Store and StoreScreenshot are not allowed for a client with a default webhook.
+func WithDefaultWebhook(hookURL, errorURL string) ClientOption {
+	return func(c *Client) error {
+		c.defaultHeaders[headerWebhookURL] = hookURL
+		c.defaultHeaders[headerWebhookErrorURL] = errorURL
+
+		return nil
+	}
+}
+
+// WithTracePrefix makes the client send a distinct Gotenberg-Trace with every request, made of the prefix and
+// a random ID, e.g., "billing-" gives "billing-" followed by 26 base32 characters. All the attempts of a retried
+// request share the same trace. A trace set on a request with Trace takes precedence.
+func WithTracePrefix(prefix string) ClientOption {
+	return func(c *Client) error {
+		c.tracePrefix = prefix
+
+		return nil
+	}
+}
+
+type traceKey struct{}
+
+// withTrace returns a context holding a new trace for the request, unless the request has its own.
+func (c *Client) withTrace(ctx context.Context, req Request) context.Context {
+	if c.tracePrefix == "" || req.customHeaders()[headerTrace] != "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, traceKey{}, c.tracePrefix+rand.Text())
+}
+
+// traceOf returns the trace generated by withTrace, if any.
+func traceOf(ctx context.Context) string {
+	trace, _ := ctx.Value(traceKey{}).(string)
+
+	return trace
+}
+
+func cloneHTTPClient(hc *http.Client) *http.Client {
+	clone := *hc
+
+	return &clone
+}
@@ -0,0 +1,163 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestClientOptions(t *testing.T) {
+	var headers http.Header
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	httpClient := &http.Client{}
+	c, err := NewClient(srv.URL, httpClient,
+		WithBasicAuth("foo", "bar"),
+		WithDefaultHeaders(map[string]string{"gotenberg-trace": "default", "X-Proxy-Token": "secret"}),
+		WithUserAgent("my-service/1.0"),
+		WithTimeout(5*time.Second),
+	)
+	require.NoError(t, err)
+	assert.Zero(t, httpClient.Timeout, "the given http.Client must not be modified")
+	assert.Equal(t, 5*time.Second, c.httpClient.Timeout)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	resp, err := c.Send(context.Background(), NewHTMLRequest(index))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	username, password, ok := (&http.Request{Header: headers}).BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, "foo", username)
+	assert.Equal(t, "bar", password)
+	assert.Equal(t, "default", headers.Get("Gotenberg-Trace"))
+	assert.Equal(t, "secret", headers.Get("X-Proxy-Token"))
+	assert.Equal(t, "my-service/1.0", headers.Get("User-Agent"))
+
+	// Request values take precedence.
+	req := NewHTMLRequest(index)
+	req.Trace("testClientOptions")
+	req.UseBasicAuth("baz", "qux")
+
+	resp, err = c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	username, _, _ = (&http.Request{Header: headers}).BasicAuth()
+	assert.Equal(t, "baz", username)
+	assert.Equal(t, "testClientOptions", headers.Get("Gotenberg-Trace"))
+
+	// Defaults are sent with requests to the other routes as well.
+	_, _ = c.Version(context.Background())
+	assert.Equal(t, "secret", headers.Get("X-Proxy-Token"))
+}
+
+func TestClientOptionsTracePrefix(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		traces []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		traces = append(traces, r.Header.Get("Gotenberg-Trace"))
+		if len(traces) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+
+	c, err := NewClient(srv.URL, nil,
+		WithTracePrefix("billing-"),
+		WithDefaultHeaders(map[string]string{"Gotenberg-Trace": "default"}),
+		WithRetryPolicy(policy),
+	)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	for range 2 {
+		resp, sendErr := c.Send(context.Background(), NewHTMLRequest(index))
+		require.NoError(t, sendErr)
+		_ = resp.Body.Close()
+	}
+
+	req := NewHTMLRequest(index)
+	req.Trace("testClientOptions")
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Len(t, traces, 4)
+	for _, trace := range traces[:3] {
+		assert.True(t, strings.HasPrefix(trace, "billing-"), trace)
+		assert.Greater(t, len(trace), len("billing-"))
+	}
+
+	assert.Equal(t, traces[0], traces[1], "attempts of a retried request share the trace")
+	assert.NotEqual(t, traces[1], traces[2], "each request gets its own trace")
+	assert.Equal(t, "testClientOptions", traces[3])
+}
+
+func TestClientOptionsTransport(t *testing.T) {
+	var called bool
+
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithTransport(transport))
+	require.NoError(t, err)
+	assert.Nil(t, http.DefaultClient.Transport, "http.DefaultClient must not be modified")
+
+	_, err = c.Version(context.Background())
+	require.Error(t, err)
+	assert.True(t, called)
+}
+
+func TestClientOptionsDefaultWebhook(t *testing.T) {
+	c, err := NewClient("http://localhost:3000", nil, WithDefaultWebhook("http://hook", "http://hook/error"))
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	err = c.Store(context.Background(), NewHTMLRequest(index), t.TempDir()+"/foo.pdf")
+	require.ErrorIs(t, err, errWebhookNotAllowed)
+
+	err = c.StoreScreenshot(context.Background(), NewHTMLRequest(index), t.TempDir()+"/foo.png")
+	require.ErrorIs(t, err, errWebhookNotAllowed)
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
@@ -0,0 +1,388 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errEmptyPool = errors.New("no hostnames passed to the pool")
+
+const defaultHealthCheckInterval = 10 * time.Second
+
+// Converter is the conversion API shared by Client and Pool.
+type Converter interface {
+	Send(ctx context.Context, req MultipartRequest) (*http.Response, error)
+	Do(ctx context.Context, req MultipartRequest) (*Result, error)
+	Store(ctx context.Context, req MultipartRequest, dest string, opts ...StoreOption) error
+	StoreAll(ctx context.Context, req MultipartRequest, dir string, opts ...StoreOption) ([]string, error)
+	StoreTo(ctx context.Context, req MultipartRequest, w io.Writer, opts ...StoreOption) (int64, error)
+	StoreIn(ctx context.Context, req MultipartRequest, sink Sink, name string) error
+	StoreAllIn(ctx context.Context, req MultipartRequest, sink Sink) ([]string, error)
+	Screenshot(ctx context.Context, scr ScreenshotRequest) (*http.Response, error)
+	DoScreenshot(ctx context.Context, scr ScreenshotRequest) (*Result, error)
+	StoreScreenshot(ctx context.Context, scr ScreenshotRequest, dest string, opts ...StoreOption) error
+	StoreScreenshotTo(ctx context.Context, scr ScreenshotRequest, w io.Writer, opts ...StoreOption) (int64, error)
+	StoreScreenshotIn(ctx context.Context, scr ScreenshotRequest, sink Sink, name string) error
+}
+
+// SelectionStrategy defines how a Pool picks the Gotenberg instance for a request.
+type SelectionStrategy int
+
+const (
+	// RoundRobin picks the instances in turn.
+	RoundRobin SelectionStrategy = iota
+	// LeastInFlight picks the instance with the fewest requests in progress.
+	LeastInFlight
+)
+
+// PoolOption configures a Pool created with NewPool.
+type PoolOption func(p *poolConfig) error
+
+type poolConfig struct {
+	strategy            SelectionStrategy
+	healthCheckInterval time.Duration
+	clientOptions       []ClientOption
+}
+
+// WithSelectionStrategy sets how the pool picks an instance for each request. Default is RoundRobin.
+func WithSelectionStrategy(strategy SelectionStrategy) PoolOption {
+	return func(p *poolConfig) error {
+		p.strategy = strategy
+
+		return nil
+	}
+}
+
+// WithHealthCheckInterval sets how often ejected instances are probed via their /health endpoint
+// to be re-admitted. Default is 10 seconds.
+func WithHealthCheckInterval(interval time.Duration) PoolOption {
+	return func(p *poolConfig) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid health check interval %s", interval)
+		}
+
+		p.healthCheckInterval = interval
+
+		return nil
+	}
+}
+
+// WithClientOptions sets the options used to create the client of each instance, e.g., WithRetryPolicy,
+// WithStreaming, WithStrictVersionCheck or WithMiddleware. Each instance gets its own version check.
+func WithClientOptions(opts ...ClientOption) PoolOption {
+	return func(p *poolConfig) error {
+		p.clientOptions = append(p.clientOptions, opts...)
+
+		return nil
+	}
+}
+
+// Pool spreads requests over several Gotenberg instances. An instance is ejected from the pool when a request
+// to it fails with a connection error or a 503 Service Unavailable response, and re-admitted once its /health
+// endpoint reports it up. If all instances are ejected, requests are spread over all of them.
+//
+// Close must be called to stop the background health checks.
+type Pool struct {
+	members  []*poolMember
+	strategy SelectionStrategy
+	next     atomic.Uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+type poolMember struct {
+	client   *Client
+	inFlight atomic.Int64
+	ejected  atomic.Bool
+}
+
+// NewPool creates a new gotenberg.Pool over the given hostnames. If http.Client is passed as nil,
+// then http.DefaultClient is used.
+func NewPool(hostnames []string, httpClient *http.Client, opts ...PoolOption) (*Pool, error) {
+	if len(hostnames) == 0 {
+		return nil, errEmptyPool
+	}
+
+	cfg := &poolConfig{
+		strategy:            RoundRobin,
+		healthCheckInterval: defaultHealthCheckInterval,
+	}
+
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &Pool{
+		members:  make([]*poolMember, 0, len(hostnames)),
+		strategy: cfg.strategy,
+		stop:     make(chan struct{}),
+	}
+
+	for _, hostname := range hostnames {
+		c, err := NewClient(hostname, httpClient, cfg.clientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("creating client for %s: %w", hostname, err)
+		}
+
+		p.members = append(p.members, &poolMember{client: c})
+	}
+
+	p.wg.Add(1)
+	go p.checkHealth(cfg.healthCheckInterval)
+
+	return p, nil
+}
+
+// Close stops the background health checks of the pool.
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.stop)
+		p.wg.Wait()
+	})
+}
+
+// Send sends a request to one of the Gotenberg instances and returns the response.
+func (p *Pool) Send(ctx context.Context, req MultipartRequest) (*http.Response, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	resp, err := m.client.Send(ctx, req)
+	p.observe(ctx, m, resp, err)
+
+	return resp, err
+}
+
+// Do sends a request to one of the Gotenberg instances and returns the result.
+func (p *Pool) Do(ctx context.Context, req MultipartRequest) (*Result, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	res, err := m.client.Do(ctx, req)
+	p.observe(ctx, m, nil, err)
+
+	return res, err
+}
+
+// Store creates the resulting file to given destination, using one of the Gotenberg instances.
+func (p *Pool) Store(ctx context.Context, req MultipartRequest, dest string, opts ...StoreOption) error {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	err := m.client.Store(ctx, req, dest, opts...)
+	p.observe(ctx, m, nil, err)
+
+	return err
+}
+
+// StoreAll stores the result of the request into the given directory, extracting it if it is a ZIP archive,
+// using one of the Gotenberg instances.
+func (p *Pool) StoreAll(ctx context.Context, req MultipartRequest, dir string, opts ...StoreOption) ([]string, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	paths, err := m.client.StoreAll(ctx, req, dir, opts...)
+	p.observe(ctx, m, nil, err)
+
+	return paths, err
+}
+
+// StoreTo writes the resulting file to w, using one of the Gotenberg instances.
+func (p *Pool) StoreTo(ctx context.Context, req MultipartRequest, w io.Writer, opts ...StoreOption) (int64, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	n, err := m.client.StoreTo(ctx, req, w, opts...)
+	p.observe(ctx, m, nil, err)
+
+	return n, err
+}
+
+// StoreIn stores the resulting file in the sink under the given name, using one of the Gotenberg instances.
+func (p *Pool) StoreIn(ctx context.Context, req MultipartRequest, sink Sink, name string) error {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	err := m.client.StoreIn(ctx, req, sink, name)
+	p.observe(ctx, m, nil, err)
+
+	return err
+}
+
+// StoreAllIn stores the files of the result in the sink, extracting them if the result is a ZIP archive,
+// using one of the Gotenberg instances.
+func (p *Pool) StoreAllIn(ctx context.Context, req MultipartRequest, sink Sink) ([]string, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	names, err := m.client.StoreAllIn(ctx, req, sink)
+	p.observe(ctx, m, nil, err)
+
+	return names, err
+}
+
+// Screenshot sends a screenshot request to one of the Gotenberg instances and returns the response.
+func (p *Pool) Screenshot(ctx context.Context, scr ScreenshotRequest) (*http.Response, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	resp, err := m.client.Screenshot(ctx, scr)
+	p.observe(ctx, m, resp, err)
+
+	return resp, err
+}
+
+// DoScreenshot sends a screenshot request to one of the Gotenberg instances and returns the result.
+func (p *Pool) DoScreenshot(ctx context.Context, scr ScreenshotRequest) (*Result, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	res, err := m.client.DoScreenshot(ctx, scr)
+	p.observe(ctx, m, nil, err)
+
+	return res, err
+}
+
+// StoreScreenshot creates the resulting image to given destination, using one of the Gotenberg instances.
+func (p *Pool) StoreScreenshot(ctx context.Context, scr ScreenshotRequest, dest string, opts ...StoreOption) error {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	err := m.client.StoreScreenshot(ctx, scr, dest, opts...)
+	p.observe(ctx, m, nil, err)
+
+	return err
+}
+
+// StoreScreenshotTo writes the resulting image to w, using one of the Gotenberg instances.
+func (p *Pool) StoreScreenshotTo(ctx context.Context, scr ScreenshotRequest, w io.Writer, opts ...StoreOption) (int64, error) {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	n, err := m.client.StoreScreenshotTo(ctx, scr, w, opts...)
+	p.observe(ctx, m, nil, err)
+
+	return n, err
+}
+
+// StoreScreenshotIn stores the resulting image in the sink under the given name, using one of the Gotenberg instances.
+func (p *Pool) StoreScreenshotIn(ctx context.Context, scr ScreenshotRequest, sink Sink, name string) error {
+	m := p.acquire()
+	defer m.inFlight.Add(-1)
+
+	err := m.client.StoreScreenshotIn(ctx, scr, sink, name)
+	p.observe(ctx, m, nil, err)
+
+	return err
+}
+
+// acquire picks an instance according to the selection strategy and counts the request as in flight.
+func (p *Pool) acquire() *poolMember {
+	candidates := make([]*poolMember, 0, len(p.members))
+	for _, m := range p.members {
+		if !m.ejected.Load() {
+			candidates = append(candidates, m)
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = p.members
+	}
+
+	// The round-robin offset is also used by LeastInFlight to spread ties.
+	offset := int((p.next.Add(1) - 1) % uint64(len(candidates)))
+	picked := candidates[offset]
+
+	if p.strategy == LeastInFlight {
+		for i := range candidates {
+			m := candidates[(offset+i)%len(candidates)]
+			if m.inFlight.Load() < picked.inFlight.Load() {
+				picked = m
+			}
+		}
+	}
+
+	picked.inFlight.Add(1)
+
+	return picked
+}
+
+// observe ejects the instance if the outcome of the request suggests it is unavailable.
+func (p *Pool) observe(ctx context.Context, m *poolMember, resp *http.Response, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusServiceUnavailable {
+		m.ejected.Store(true)
+
+		return
+	}
+
+	var gErr *GotenbergError
+	if errors.As(err, &gErr) && gErr.StatusCode == http.StatusServiceUnavailable {
+		m.ejected.Store(true)
+
+		return
+	}
+
+	// Only connection errors count: a document which fails to be read while streaming is not the instance fault.
+	var opErr *net.OpError
+	if errors.Is(err, errSendRequestFailed) && errors.As(err, &opErr) {
+		m.ejected.Store(true)
+	}
+}
+
+// checkHealth periodically re-admits the ejected instances which report themselves up.
+func (p *Pool) checkHealth(interval time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			for _, m := range p.members {
+				if m.ejected.Load() && p.isHealthy(m, interval) {
+					m.ejected.Store(false)
+				}
+			}
+		}
+	}
+}
+
+func (p *Pool) isHealthy(m *poolMember, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-p.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	report, err := m.client.Health(ctx)
+
+	return err == nil && report.IsUp()
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Converter(new(Client))
+	_ = Converter(new(Pool))
+)
@@ -0,0 +1,186 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestPool(t *testing.T) {
+	var (
+		unavailable        atomic.Bool
+		healthy, unhealthy atomic.Int32
+	)
+
+	unavailable.Store(true)
+
+	healthySrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		healthy.Add(1)
+	}))
+	defer healthySrv.Close()
+
+	unhealthySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == endpointHealth {
+			if unavailable.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"status":"down"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status":"up"}`))
+
+			return
+		}
+
+		unhealthy.Add(1)
+		if unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer unhealthySrv.Close()
+
+	pool, err := NewPool([]string{unhealthySrv.URL, healthySrv.URL}, nil,
+		WithHealthCheckInterval(20*time.Millisecond),
+		WithClientOptions(WithBasicAuth("foo", "bar")),
+	)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	send := func() {
+		resp, sendErr := pool.Send(context.Background(), NewMergeRequest(pdf))
+		require.NoError(t, sendErr)
+		_ = resp.Body.Close()
+	}
+
+	// The first request goes to the unavailable instance, which gets ejected.
+	for range 4 {
+		send()
+	}
+	assert.Equal(t, int32(1), unhealthy.Load())
+	assert.Equal(t, int32(3), healthy.Load())
+
+	// The instance is re-admitted once it reports itself up.
+	unavailable.Store(false)
+	require.Eventually(t, func() bool {
+		return !pool.members[0].ejected.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	for range 4 {
+		send()
+	}
+	assert.Equal(t, int32(3), unhealthy.Load())
+	assert.Equal(t, int32(5), healthy.Load())
+}
+
+func TestPoolClientOptions(t *testing.T) {
+	var versionCalls, conversions atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == endpointVersion {
+			versionCalls.Add(1)
+			_, _ = w.Write([]byte("8.10.0"))
+
+			return
+		}
+
+		_, _ = io.Copy(io.Discard, r.Body)
+		if conversions.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	var calls, streamed atomic.Int32
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.Jitter = 0
+
+	pool, err := NewPool([]string{srv.URL}, nil, WithClientOptions(
+		WithRetryPolicy(policy),
+		WithStreaming(),
+		WithStrictVersionCheck(),
+		WithMiddleware(func(next Handler) Handler {
+			return func(call *Call) (*http.Response, error) {
+				calls.Add(1)
+				if _, ok := call.HTTPRequest.Body.(*io.PipeReader); ok {
+					streamed.Add(1)
+				}
+
+				return next(call)
+			}
+		}),
+	))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+
+	// The first attempt is retried.
+	resp, err := pool.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(2), streamed.Load())
+
+	// The version of the instance is checked.
+	req.GenerateTaggedPDF(true)
+	_, err = pool.Send(context.Background(), req)
+	require.ErrorIs(t, err, errUnsupportedByServer)
+	assert.Equal(t, int32(1), versionCalls.Load())
+	assert.Equal(t, int32(2), conversions.Load())
+}
+
+func TestPoolConnectionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	closedSrv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	closedSrv.Close()
+
+	pool, err := NewPool([]string{closedSrv.URL, srv.URL}, nil, WithSelectionStrategy(LeastInFlight))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	_, err = pool.Send(context.Background(), NewMergeRequest(pdf))
+	require.ErrorIs(t, err, errSendRequestFailed)
+	assert.True(t, pool.members[0].ejected.Load())
+
+	err = pool.Store(context.Background(), NewMergeRequest(pdf), t.TempDir()+"/foo.pdf")
+	require.NoError(t, err)
+}
+
+func TestPoolLeastInFlight(t *testing.T) {
+	pool, err := NewPool([]string{"http://a", "http://b", "http://c"}, nil, WithSelectionStrategy(LeastInFlight))
+	require.NoError(t, err)
+	defer pool.Close()
+
+	first := pool.acquire()
+	second := pool.acquire()
+	third := pool.acquire()
+	assert.NotSame(t, first, second)
+	assert.NotSame(t, second, third)
+	assert.NotSame(t, first, third)
+
+	second.inFlight.Add(-1)
+	assert.Same(t, second, pool.acquire())
+}
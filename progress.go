@@ -0,0 +1,112 @@
+package gotenberg
+
+import (
+	"io"
+	"net/http"
+)
+
+// ProgressDirection tells whether a Progress reports an upload or a download.
+type ProgressDirection int
+
+const (
+	// ProgressUpload reports the bytes of a document sent to Gotenberg.
+	ProgressUpload ProgressDirection = iota
+	// ProgressDownload reports the bytes of the result received from Gotenberg.
+	ProgressDownload
+)
+
+// Progress is a progress report of a request.
+type Progress struct {
+	Direction ProgressDirection
+	// Name is the filename of the uploaded document, or of the downloaded result if Gotenberg sent it.
+	Name string
+	// Bytes is the number of bytes transferred so far.
+	Bytes int64
+	// Total is the size of the document or of the result, or -1 if it is unknown.
+	Total int64
+	// Done is true once the document or the result has been transferred entirely.
+	Done bool
+}
+
+// ProgressFunc receives progress reports. It is called synchronously from the goroutine reading the document
+// or the result, so it must be fast; a request may report the progress of several documents, in any order.
+//
+// NOTE: upload progress is measured while the multipart form is written. Without WithStreaming, the form is built
+// in memory before being sent, so the upload reports reflect that rather than the network transfer.
+type ProgressFunc func(p Progress)
+
+// WithProgress sets the function receiving the upload and download progress of every request.
+// A function set on a request with OnProgress takes precedence.
+func WithProgress(fn ProgressFunc) ClientOption {
+	return func(c *Client) error {
+		c.progress = fn
+
+		return nil
+	}
+}
+
+// OnProgress sets the function receiving the upload and download progress of the request.
+func (br *baseRequest) OnProgress(fn ProgressFunc) {
+	br.progress = fn
+}
+
+func (br *baseRequest) progressFunc() ProgressFunc {
+	return br.progress
+}
+
+// progressOf returns the progress function of the request, or the client default.
+func (c *Client) progressOf(req Request) ProgressFunc {
+	if fn := req.progressFunc(); fn != nil {
+		return fn
+	}
+
+	return c.progress
+}
+
+// progressReader reports the bytes read from r.
+type progressReader struct {
+	r        io.Reader
+	fn       ProgressFunc
+	progress Progress
+}
+
+func newProgressReader(r io.Reader, fn ProgressFunc, direction ProgressDirection, name string, total int64) *progressReader {
+	return &progressReader{
+		r:        r,
+		fn:       fn,
+		progress: Progress{Direction: direction, Name: name, Total: total},
+	}
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+
+	if n > 0 || (err == io.EOF && !pr.progress.Done) {
+		pr.progress.Bytes += int64(n)
+		pr.progress.Done = err == io.EOF
+		pr.fn(pr.progress)
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
+
+// progressBody reports the download progress of a response body.
+type progressBody struct {
+	*progressReader
+
+	body io.ReadCloser
+}
+
+func (pb *progressBody) Close() error {
+	return pb.body.Close() //nolint:wrapcheck // the body error is returned as is.
+}
+
+// trackDownload makes the response body report its download progress.
+func trackDownload(resp *http.Response, fn ProgressFunc) {
+	name := parseContentDispositionFilename(resp.Header.Get("Content-Disposition"))
+
+	resp.Body = &progressBody{
+		progressReader: newProgressReader(resp.Body, fn, ProgressDownload, name, resp.ContentLength),
+		body:           resp.Body,
+	}
+}
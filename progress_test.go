@@ -0,0 +1,84 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestProgress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Disposition", `attachment; filename="foo.pdf"`)
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	var (
+		mu     sync.Mutex
+		events = make(map[string]Progress)
+	)
+
+	c, err := NewClient(srv.URL, nil, WithProgress(func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		events[p.Name] = p
+	}), WithStreaming())
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	style, err := document.FromString("style.css", "body {}")
+	require.NoError(t, err)
+
+	req := NewHTMLRequest(index)
+	req.Assets(style)
+
+	data, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+	_, err = data.Bytes()
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, Progress{Direction: ProgressUpload, Name: "index.html", Bytes: 16, Total: 16, Done: true}, events["index.html"])
+	assert.Equal(t, Progress{Direction: ProgressUpload, Name: "style.css", Bytes: 7, Total: 7, Done: true}, events["style.css"])
+	assert.Equal(t, Progress{Direction: ProgressDownload, Name: "foo.pdf", Bytes: 8, Total: 8, Done: true}, events["foo.pdf"])
+}
+
+func TestProgressPerRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	var clientCalls, requestCalls int
+
+	c, err := NewClient(srv.URL, nil, WithProgress(func(Progress) {
+		clientCalls++
+	}))
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+
+	req := NewHTMLRequest(index)
+	req.OnProgress(func(Progress) {
+		requestCalls++
+	})
+
+	_, err = c.StoreTo(context.Background(), req, io.Discard)
+	require.NoError(t, err)
+	assert.Zero(t, clientCalls, "the request progress function takes precedence")
+	assert.Positive(t, requestCalls)
+}
@@ -0,0 +1,189 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const contentTypeZip = "application/zip"
+
+// maxPreallocSize caps the buffer allocated by Result.Bytes from the announced size, which comes from the
+// Content-Length header and cannot be trusted; larger results grow the buffer while being read.
+const maxPreallocSize = 16 << 20
+
+// Result is a successful response of the Gotenberg API. Its body must be consumed by one of Bytes, WriteTo
+// or SaveTo, which close it, or read and closed explicitly.
+type Result struct {
+	// Filename is the filename chosen by Gotenberg, from the Content-Disposition header.
+	Filename string
+	// ContentType is the media type of the resulting file, e.g., application/pdf or application/zip.
+	ContentType string
+	// Trace is the value of the Gotenberg-Trace response header.
+	Trace string
+	// Size is the size of the resulting file in bytes, or -1 if it is unknown.
+	Size int64
+	// StatusCode is the HTTP status code of the response, e.g., 204 No Content for webhook requests.
+	StatusCode int
+
+	// contentType and contentDisposition are the raw response headers, forwarded by CopyHeaders.
+	contentType        string
+	contentDisposition string
+
+	body          io.ReadCloser
+	archiveLimits ArchiveLimits
+	timer         *timer
+	err           error
+}
+
+// Do sends a request to the Gotenberg API and returns the result. Non-successful responses are returned
+// as a *GotenbergError.
+func (c *Client) Do(ctx context.Context, req MultipartRequest) (*Result, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newResult(resp, req, req.endpoint())
+}
+
+// DoScreenshot sends a screenshot request to the Gotenberg API and returns the result. Non-successful responses
+// are returned as a *GotenbergError.
+func (c *Client) DoScreenshot(ctx context.Context, scr ScreenshotRequest) (*Result, error) {
+	resp, err := c.screenshot(ctx, scr)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newResult(resp, scr, scr.screenshotEndpoint())
+}
+
+// newResult wraps a response into a Result, or converts it into a *GotenbergError and closes it.
+func (c *Client) newResult(resp *http.Response, req Request, endpoint string) (*Result, error) {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		return nil, newGotenbergError(resp, req, endpoint)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	res := &Result{
+		Filename:    parseContentDispositionFilename(resp.Header.Get("Content-Disposition")),
+		ContentType: contentType,
+		Trace:       resp.Header.Get(string(headerTrace)),
+		Size:        resp.ContentLength,
+		StatusCode:  resp.StatusCode,
+
+		contentType:        resp.Header.Get("Content-Type"),
+		contentDisposition: resp.Header.Get("Content-Disposition"),
+
+		body:          resp.Body,
+		archiveLimits: c.archiveLimits,
+		timer:         timerOf(resp),
+	}
+
+	return res, nil
+}
+
+func parseContentDispositionFilename(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(value)
+	if err != nil || params["filename"] == "" {
+		return ""
+	}
+
+	// The filename comes from the server: never let it designate another directory.
+	name := filepath.Base(filepath.Clean("/" + params["filename"]))
+	if name == string(filepath.Separator) {
+		return ""
+	}
+
+	return name
+}
+
+// CopyHeaders sets the Content-Type, Content-Disposition and Content-Length headers of the result to h,
+// e.g., the headers of an http.ResponseWriter the result is forwarded to. Headers unknown for the result are left as is.
+func (r *Result) CopyHeaders(h http.Header) {
+	if r.contentType != "" {
+		h.Set("Content-Type", r.contentType)
+	}
+
+	if r.contentDisposition != "" {
+		h.Set("Content-Disposition", r.contentDisposition)
+	}
+
+	if r.Size >= 0 {
+		h.Set("Content-Length", strconv.FormatInt(r.Size, 10))
+	}
+}
+
+// IsArchive reports whether the result is a ZIP archive, e.g., the output of a split
+// or of a conversion of several files without merging them.
+func (r *Result) IsArchive() bool {
+	return r.ContentType == contentTypeZip || strings.EqualFold(filepath.Ext(r.Filename), ".zip")
+}
+
+// Read reads the result body.
+func (r *Result) Read(p []byte) (int, error) {
+	return r.body.Read(p)
+}
+
+// Close closes the result body.
+func (r *Result) Close() error {
+	return r.body.Close()
+}
+
+// Bytes reads the whole result into memory and closes it.
+func (r *Result) Bytes() ([]byte, error) {
+	defer func() {
+		_ = r.Close()
+	}()
+
+	buf := &bytes.Buffer{}
+	if r.Size > 0 {
+		buf.Grow(int(min(r.Size, maxPreallocSize)))
+	}
+
+	if _, err := buf.ReadFrom(r.body); err != nil {
+		return nil, fmt.Errorf("reading result: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteTo writes the result to w and closes it.
+func (r *Result) WriteTo(w io.Writer) (int64, error) {
+	defer func() {
+		_ = r.Close()
+	}()
+
+	n, err := io.Copy(w, r.body)
+	if err != nil {
+		return n, fmt.Errorf("writing result: %w", err)
+	}
+
+	return n, nil
+}
+
+// SaveTo atomically writes the result to the given file path, creating the missing directories, and closes it.
+func (r *Result) SaveTo(fpath string, opts ...StoreOption) error {
+	return r.SaveIn(NewDirSink(filepath.Dir(fpath), opts...), filepath.Base(fpath))
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = io.ReadCloser(new(Result))
+	_ = io.WriterTo(new(Result))
+)
@@ -0,0 +1,93 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == endpointHTMLScreenshot {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid form data"))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="foo.pdf"`)
+		w.Header().Set("Gotenberg-Trace", "testDo")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+
+	res, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "foo.pdf", res.Filename)
+	assert.Equal(t, "application/pdf", res.ContentType)
+	assert.Equal(t, "testDo", res.Trace)
+	assert.Equal(t, int64(8), res.Size)
+	assert.False(t, res.IsArchive())
+
+	data, err := res.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-1.7"), data)
+
+	// A bogus size must not be allocated upfront.
+	res = &Result{Size: 1 << 62, body: io.NopCloser(bytes.NewReader([]byte("%PDF-1.7")))}
+	data, err = res.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-1.7"), data)
+
+	res, err = c.Do(context.Background(), req)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := res.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+
+	res, err = c.Do(context.Background(), req)
+	require.NoError(t, err)
+
+	dest := filepath.Join(t.TempDir(), "out", "foo.pdf")
+	require.NoError(t, res.SaveTo(dest))
+	data, err = os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-1.7"), data)
+
+	_, err = c.DoScreenshot(context.Background(), req)
+
+	var gErr *GotenbergError
+	require.ErrorAs(t, err, &gErr)
+	assert.True(t, gErr.IsBadRequest())
+	assert.Equal(t, "Invalid form data", gErr.Message)
+}
+
+func TestParseContentDispositionFilename(t *testing.T) {
+	assert.Equal(t, "foo.zip", parseContentDispositionFilename(`attachment; filename="foo.zip"`))
+	assert.Equal(t, "passwd", parseContentDispositionFilename(`attachment; filename="../../etc/passwd"`))
+	assert.Empty(t, parseContentDispositionFilename(`attachment; filename="/"`))
+	assert.Empty(t, parseContentDispositionFilename("attachment"))
+	assert.Empty(t, parseContentDispositionFilename(""))
+	assert.True(t, (&Result{Filename: "foo.ZIP"}).IsArchive())
+	assert.True(t, (&Result{ContentType: contentTypeZip}).IsArchive())
+}
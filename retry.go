@@ -0,0 +1,147 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries requests that failed because of a transient error, e.g.,
+// a 503 Service Unavailable response when the Chromium or LibreOffice queue of Gotenberg is full.
+//
+// The multipart body is rebuilt from the request documents for each attempt, so documents must be readable
+// more than once.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, including those requested with Retry-After. Zero means no limit.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the delay grows after each attempt. Values lower than 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes each delay by up to the given fraction (between 0 and 1) in both directions.
+	Jitter float64
+	// RetryableStatusCodes lists the response status codes which trigger a retry.
+	RetryableStatusCodes []int
+	// RetryNetworkErrors enables retrying requests which failed before a response was received.
+	RetryNetworkErrors bool
+	// IgnoreRetryAfter disables honoring the Retry-After response header.
+	IgnoreRetryAfter bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and an exponential backoff starting at 500ms,
+// which retries network errors and 429, 502, 503 and 504 responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// shouldRetry reports whether an attempt which ended with the given response or error can be retried.
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// Errors occurring before sending, or while reading documents when streaming, are not transient.
+		return p.RetryNetworkErrors && errors.Is(err, errSendRequestFailed) && !errors.Is(err, errReadDocument)
+	}
+
+	return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+}
+
+// backoff returns the delay to wait before the given retry (starting at 1).
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil && !p.IgnoreRetryAfter {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 {
+				return min(delay, p.MaxBackoff)
+			}
+
+			return delay
+		}
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+
+	if p.MaxBackoff > 0 {
+		delay = math.Min(delay, float64(p.MaxBackoff))
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1) //nolint:gosec // jitter does not need a secure source.
+	}
+
+	return time.Duration(delay)
+}
+
+// parseRetryAfter parses the Retry-After header value, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Until(date), 0), true
+}
+
+// WithRetryPolicy makes the client retry failed requests according to the given policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// discardResponse drains and closes the body of a response which will not be returned to the caller,
+// so the underlying connection can be reused.
+func discardResponse(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+	_ = resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package gotenberg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each attempt must carry the whole multipart body.
+		if err := r.ParseMultipartForm(1 << 20); err != nil || len(r.MultipartForm.File["files"]) != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte("%PDF-"))
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+
+	c, err := NewClient(srv.URL, nil, WithRetryPolicy(policy))
+	require.NoError(t, err)
+
+	pdf1, err := document.FromString("gotenberg1.pdf", "%PDF-")
+	require.NoError(t, err)
+	pdf2, err := document.FromString("gotenberg2.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	resp, err := c.Send(context.Background(), NewMergeRequest(pdf1, pdf2))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// Attempts are exhausted: the last response is returned.
+	attempts.Store(-10)
+	err = c.Store(context.Background(), NewMergeRequest(pdf1, pdf2), t.TempDir()+"/foo.pdf")
+
+	var gErr *GotenbergError
+	require.ErrorAs(t, err, &gErr)
+	assert.Equal(t, http.StatusServiceUnavailable, gErr.StatusCode)
+	assert.Equal(t, int32(-7), attempts.Load())
+}
+
+func TestRetryPolicyContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithRetryPolicy(DefaultRetryPolicy()))
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Send(ctx, NewMergeRequest(pdf))
+	require.ErrorIs(t, err, errSendRequestFailed)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The response received before the context ended is not lost.
+	var gErr *GotenbergError
+	require.ErrorAs(t, err, &gErr)
+	assert.Equal(t, http.StatusServiceUnavailable, gErr.StatusCode)
+}
+
+func TestRetryPolicyDocumentError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+
+	// The one-shot document cannot be read again by the second attempt, which must not be retried. In buffered
+	// mode, the attempt fails before being sent, so the handler chain is called once.
+	tests := []struct {
+		name      string
+		streaming bool
+		calls     int32
+	}{
+		{name: "Buffered", calls: 1},
+		{name: "Streaming", streaming: true, calls: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+
+			opts := []ClientOption{
+				WithRetryPolicy(policy),
+				WithMiddleware(func(next Handler) Handler {
+					return func(call *Call) (*http.Response, error) {
+						calls.Add(1)
+
+						return next(call)
+					}
+				}),
+			}
+			if tc.streaming {
+				opts = append(opts, WithStreaming())
+			}
+
+			c, err := NewClient(srv.URL, nil, opts...)
+			require.NoError(t, err)
+
+			pdf, err := document.FromReader("gotenberg.pdf", strings.NewReader("%PDF-"))
+			require.NoError(t, err)
+
+			_, err = c.Send(context.Background(), NewMergeRequest(pdf))
+			require.ErrorIs(t, err, errReadDocument)
+			assert.Equal(t, tc.calls, calls.Load())
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2, nil))
+	assert.Equal(t, 300*time.Millisecond, policy.backoff(3, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	assert.Equal(t, 300*time.Millisecond, policy.backoff(1, resp), "Retry-After is capped by MaxBackoff")
+
+	policy.MaxBackoff = 0
+	assert.Equal(t, 2*time.Second, policy.backoff(1, resp))
+	policy.MaxBackoff = 300 * time.Millisecond
+
+	policy.IgnoreRetryAfter = true
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1, resp))
+
+	policy.Jitter = 0.5
+	for range 10 {
+		delay := policy.backoff(1, nil)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 150*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("-1")
+	assert.False(t, ok)
+}
@@ -2,7 +2,7 @@ package gotenberg
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"net/http"
 )
 
@@ -17,39 +17,36 @@ func (c *Client) Screenshot(ctx context.Context, scr ScreenshotRequest) (*http.R
 }
 
 func (c *Client) screenshot(ctx context.Context, scr ScreenshotRequest) (*http.Response, error) {
-	req, err := c.createRequest(ctx, scr, scr.screenshotEndpoint())
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errSendRequestFailed, err)
-	}
-
-	return resp, nil
+	return c.do(ctx, scr, scr.screenshotEndpoint())
 }
 
-func (c *Client) StoreScreenshot(ctx context.Context, scr ScreenshotRequest, dest string) error {
-	return c.storeScreenshot(ctx, scr, dest)
+func (c *Client) StoreScreenshot(ctx context.Context, scr ScreenshotRequest, dest string, opts ...StoreOption) error {
+	return c.storeScreenshot(ctx, scr, dest, opts...)
 }
 
-func (c *Client) storeScreenshot(ctx context.Context, scr ScreenshotRequest, dest string) error {
-	if hasWebhook(scr) {
+func (c *Client) storeScreenshot(ctx context.Context, scr ScreenshotRequest, dest string, opts ...StoreOption) error {
+	if c.hasWebhook(scr) {
 		return errWebhookNotAllowed
 	}
 
-	resp, err := c.screenshot(ctx, scr)
+	res, err := c.DoScreenshot(ctx, scr)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %d", errGenerationFailed, resp.StatusCode)
+	return res.SaveTo(dest, opts...)
+}
+
+// StoreScreenshotTo writes the resulting image to w, e.g., an http.ResponseWriter, and returns the number of bytes written.
+func (c *Client) StoreScreenshotTo(ctx context.Context, scr ScreenshotRequest, w io.Writer, opts ...StoreOption) (int64, error) {
+	if c.hasWebhook(scr) {
+		return 0, errWebhookNotAllowed
+	}
+
+	res, err := c.DoScreenshot(ctx, scr)
+	if err != nil {
+		return 0, err
 	}
 
-	return writeNewFile(dest, resp.Body)
+	return writeResult(res, w, newStoreOptions(opts))
 }
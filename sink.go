@@ -0,0 +1,293 @@
+package gotenberg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+)
+
+// Sink is a destination for the files of a result, e.g., a directory or an object storage bucket.
+//
+// Files are written with Create then closed; none of them must be visible before Commit is called.
+// If anything fails, Abort is called instead and the written files must be discarded. A Sink is used
+// by one store operation at a time, and may be reused once it has been committed or aborted.
+type Sink interface {
+	// Create returns a writer for the file with the given name, a relative slash-separated path.
+	Create(name string) (io.WriteCloser, error)
+	// Commit publishes the files written since the last Commit or Abort.
+	Commit() error
+	// Abort discards the files written since the last Commit or Abort.
+	Abort() error
+}
+
+// SaveIn writes the result to the sink under the given name, commits it, and closes the result.
+func (r *Result) SaveIn(sink Sink, name string) error {
+	defer func() {
+		_ = r.Close()
+	}()
+
+	name, err := safeEntryName(name)
+	if err != nil {
+		return err
+	}
+
+	if err = writeToSink(sink, name, r.body); err != nil {
+		return errors.Join(err, sink.Abort())
+	}
+
+	return sink.Commit() //nolint:wrapcheck // sink errors are returned as is.
+}
+
+// SaveAllIn writes the files of the result to the sink, extracting them if the result is a ZIP archive,
+// commits them, and closes the result. It returns the names of the files.
+func (r *Result) SaveAllIn(sink Sink) ([]string, error) {
+	var (
+		names []string
+		err   error
+	)
+
+	for name, f := range r.Files() {
+		if err = writeToSink(sink, name, f); err != nil {
+			break
+		}
+
+		names = append(names, name)
+	}
+
+	if err == nil {
+		err = r.Err()
+	}
+
+	if err != nil {
+		return nil, errors.Join(err, sink.Abort())
+	}
+
+	if err = sink.Commit(); err != nil {
+		return nil, err //nolint:wrapcheck // sink errors are returned as is.
+	}
+
+	return names, nil
+}
+
+func writeToSink(sink Sink, name string, r io.Reader) error {
+	w, err := sink.Create(name)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+
+	if _, err = io.Copy(w, r); err != nil {
+		_ = w.Close()
+
+		return fmt.Errorf("writing to %s: %w", name, err)
+	}
+
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// StoreIn stores the resulting file in the sink under the given name.
+func (c *Client) StoreIn(ctx context.Context, req MultipartRequest, sink Sink, name string) error {
+	if c.hasWebhook(req) {
+		return errWebhookNotAllowed
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return res.SaveIn(sink, name)
+}
+
+// StoreAllIn stores the files of the result in the sink, extracting them if the result is a ZIP archive,
+// and returns their names.
+func (c *Client) StoreAllIn(ctx context.Context, req MultipartRequest, sink Sink) ([]string, error) {
+	if c.hasWebhook(req) {
+		return nil, errWebhookNotAllowed
+	}
+
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.SaveAllIn(sink)
+}
+
+// StoreScreenshotIn stores the resulting image in the sink under the given name.
+func (c *Client) StoreScreenshotIn(ctx context.Context, scr ScreenshotRequest, sink Sink, name string) error {
+	if c.hasWebhook(scr) {
+		return errWebhookNotAllowed
+	}
+
+	res, err := c.DoScreenshot(ctx, scr)
+	if err != nil {
+		return err
+	}
+
+	return res.SaveIn(sink, name)
+}
+
+// DirSink is a Sink writing files into a directory. Files are written to temporary files next to
+// their destination and renamed on Commit, so that a destination never holds a partial file.
+//
+// NOTE: Commit renames the files one by one; it is atomic for each file, not for all of them.
+type DirSink struct {
+	dir     string
+	opts    storeOptions
+	pending []*atomicFile
+}
+
+// NewDirSink creates a DirSink writing into dir, which is created if missing. Store options apply to every file.
+func NewDirSink(dir string, opts ...StoreOption) *DirSink {
+	return &DirSink{dir: dir, opts: newStoreOptions(opts)}
+}
+
+// Create creates a temporary file for the given name.
+func (s *DirSink) Create(name string) (io.WriteCloser, error) {
+	name, err := safeEntryName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	af, err := createAtomicFile(filepath.Join(s.dir, filepath.FromSlash(name)), s.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.pending = append(s.pending, af)
+
+	return af, nil
+}
+
+// Commit moves the temporary files to their destination.
+func (s *DirSink) Commit() error {
+	pending := s.pending
+	s.pending = nil
+
+	for i, af := range pending {
+		if err := af.commit(); err != nil {
+			for _, rest := range pending[i+1:] {
+				rest.abort()
+			}
+
+			return err
+		}
+	}
+
+	if len(pending) == 1 && s.opts.sha256 != nil {
+		*s.opts.sha256 = pending[0].sum
+	}
+
+	return nil
+}
+
+// Abort removes the temporary files.
+func (s *DirSink) Abort() error {
+	for _, af := range s.pending {
+		af.abort()
+	}
+
+	s.pending = nil
+
+	return nil
+}
+
+// MemorySink is a Sink keeping files in memory, e.g., for tests or to post-process small results.
+//
+// NOTE: like any Sink, a MemorySink must not be shared by concurrent store operations, as Abort discards
+// the files of all of them. File and Names may be called while a store operation is in progress.
+type MemorySink struct {
+	mu      sync.Mutex
+	files   map[string][]byte
+	pending map[string][]byte
+}
+
+// NewMemorySink creates an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{
+		files:   make(map[string][]byte),
+		pending: make(map[string][]byte),
+	}
+}
+
+// Create returns a buffer for the given name.
+func (s *MemorySink) Create(name string) (io.WriteCloser, error) {
+	return &memoryFile{sink: s, name: name}, nil
+}
+
+// Commit publishes the closed buffers, replacing files with the same names.
+func (s *MemorySink) Commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, data := range s.pending {
+		s.files[name] = data
+	}
+
+	clear(s.pending)
+
+	return nil
+}
+
+// Abort discards the buffers written since the last Commit.
+func (s *MemorySink) Abort() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.pending)
+
+	return nil
+}
+
+// File returns the content of the committed file with the given name.
+func (s *MemorySink) File(name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.files[name]
+
+	return data, ok
+}
+
+// Names returns the names of the committed files, in no particular order.
+func (s *MemorySink) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.files))
+	for name := range s.files {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+type memoryFile struct {
+	bytes.Buffer
+
+	sink *MemorySink
+	name string
+}
+
+func (f *memoryFile) Close() error {
+	f.sink.mu.Lock()
+	defer f.sink.mu.Unlock()
+
+	f.sink.pending[f.name] = f.Bytes()
+
+	return nil
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Sink(new(DirSink))
+	_ = Sink(new(MemorySink))
+)
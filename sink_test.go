@@ -0,0 +1,68 @@
+package gotenberg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestStoreAllInMemorySink(t *testing.T) {
+	srv := archiveServer(t, zipArchive(t, map[string]string{
+		"gotenberg_1.pdf":     "%PDF-1",
+		"sub/gotenberg_2.pdf": "%PDF-2",
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	sink := NewMemorySink()
+	names, err := c.StoreAllIn(context.Background(), NewSplitPagesRequest(pdf), sink)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"gotenberg_1.pdf", "sub/gotenberg_2.pdf"}, names)
+	assert.ElementsMatch(t, names, sink.Names())
+
+	data, ok := sink.File("sub/gotenberg_2.pdf")
+	require.True(t, ok)
+	assert.Equal(t, "%PDF-2", string(data))
+}
+
+func TestStoreAllInAbort(t *testing.T) {
+	srv := archiveServer(t, zipArchive(t, map[string]string{"a.pdf": "0123", "b.pdf": "0123456789"}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithArchiveLimits(ArchiveLimits{MaxEntrySize: 5}))
+	require.NoError(t, err)
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+
+	sink := NewMemorySink()
+	_, err = c.StoreAllIn(context.Background(), NewSplitPagesRequest(pdf), sink)
+	require.ErrorIs(t, err, errArchiveLimit)
+	assert.Empty(t, sink.Names(), "nothing must be committed on failure")
+
+	dir := t.TempDir()
+	_, err = c.StoreAllIn(context.Background(), NewSplitPagesRequest(pdf), NewDirSink(dir))
+	require.ErrorIs(t, err, errArchiveLimit)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary files must be removed on failure")
+}
+
+func TestDirSinkUnsafeName(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewDirSink(filepath.Join(dir, "out")).Create("../evil.pdf")
+	require.ErrorIs(t, err, errUnsafeEntryName)
+}
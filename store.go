@@ -0,0 +1,214 @@
+package gotenberg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+var errFileExists = errors.New("destination file already exists")
+
+const (
+	defaultFileMode fs.FileMode = 0o644
+	defaultDirMode  fs.FileMode = 0o755
+)
+
+// StoreOption configures how Store, StoreScreenshot, StoreAll, Result.SaveTo and a DirSink write files.
+type StoreOption func(o *storeOptions)
+
+type storeOptions struct {
+	fileMode    fs.FileMode
+	dirMode     fs.FileMode
+	noOverwrite bool
+	sha256      *string
+
+	forwardHeaders bool
+}
+
+func newStoreOptions(opts []StoreOption) storeOptions {
+	o := storeOptions{
+		fileMode: defaultFileMode,
+		dirMode:  defaultDirMode,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithFileMode sets the permissions of the created files (0644 by default). It is ignored on Windows.
+func WithFileMode(mode fs.FileMode) StoreOption {
+	return func(o *storeOptions) {
+		o.fileMode = mode.Perm()
+	}
+}
+
+// WithDirMode sets the permissions of the created missing directories (0755 by default).
+func WithDirMode(mode fs.FileMode) StoreOption {
+	return func(o *storeOptions) {
+		o.dirMode = mode.Perm()
+	}
+}
+
+// WithNoOverwrite makes storing fail with an error matching fs.ErrExist if the destination file already exists.
+// The check and the creation of the file are atomic where the file system supports hard links.
+func WithNoOverwrite() StoreOption {
+	return func(o *storeOptions) {
+		o.noOverwrite = true
+	}
+}
+
+// WithSHA256 sets sum to the hex-encoded SHA-256 digest of the stored file, computed while it is written.
+// It is ignored when several files are stored at once, e.g., by StoreAll.
+func WithSHA256(sum *string) StoreOption {
+	return func(o *storeOptions) {
+		o.sha256 = sum
+	}
+}
+
+// WithForwardedHeaders makes StoreTo and StoreScreenshotTo copy the Content-Type, Content-Disposition and
+// Content-Length headers of the result to the writer, if it is an http.ResponseWriter, before writing the result.
+func WithForwardedHeaders() StoreOption {
+	return func(o *storeOptions) {
+		o.forwardHeaders = true
+	}
+}
+
+// writeResult writes the result to w, forwarding its headers if requested, and closes it.
+func writeResult(res *Result, w io.Writer, opts storeOptions) (int64, error) {
+	if rw, ok := w.(http.ResponseWriter); ok && opts.forwardHeaders {
+		res.CopyHeaders(rw.Header())
+	}
+
+	return res.WriteTo(w)
+}
+
+// atomicFile is a temporary file which replaces its destination once committed.
+type atomicFile struct {
+	f    *os.File
+	dest string
+	opts storeOptions
+	hash hash.Hash
+	sum  string
+}
+
+func createAtomicFile(dest string, opts storeOptions) (*atomicFile, error) {
+	dir := filepath.Dir(dest)
+
+	if err := os.MkdirAll(dir, opts.dirMode); err != nil {
+		return nil, fmt.Errorf("making %s directory: %w", dest, err)
+	}
+
+	if opts.noOverwrite {
+		// Fail early rather than after receiving the whole file; commit checks again.
+		if _, err := os.Lstat(dest); err == nil {
+			return nil, fmt.Errorf("%w: %s: %w", errFileExists, dest, fs.ErrExist)
+		}
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(dest)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("creating %s: %w", dest, err)
+	}
+
+	af := &atomicFile{f: f, dest: dest, opts: opts}
+	if opts.sha256 != nil {
+		af.hash = sha256.New()
+	}
+
+	return af, nil
+}
+
+func (af *atomicFile) Write(p []byte) (int, error) {
+	n, err := af.f.Write(p)
+	if af.hash != nil {
+		af.hash.Write(p[:n])
+	}
+
+	return n, err //nolint:wrapcheck // io.Writer errors are returned as is.
+}
+
+// Close syncs and closes the temporary file, which is then ready to be committed.
+func (af *atomicFile) Close() error {
+	if err := af.f.Chmod(af.opts.fileMode); err != nil && runtime.GOOS != "windows" {
+		_ = af.f.Close()
+
+		return fmt.Errorf("setting %s permissions: %w", af.dest, err)
+	}
+
+	if err := af.f.Sync(); err != nil {
+		_ = af.f.Close()
+
+		return fmt.Errorf("syncing %s: %w", af.dest, err)
+	}
+
+	if err := af.f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", af.dest, err)
+	}
+
+	if af.hash != nil {
+		af.sum = hex.EncodeToString(af.hash.Sum(nil))
+	}
+
+	return nil
+}
+
+// commit moves the closed temporary file to its destination.
+func (af *atomicFile) commit() error {
+	if err := af.rename(); err != nil {
+		af.abort()
+
+		return err
+	}
+
+	return nil
+}
+
+func (af *atomicFile) rename() error {
+	if !af.opts.noOverwrite {
+		if err := os.Rename(af.f.Name(), af.dest); err != nil {
+			return fmt.Errorf("renaming %s: %w", af.dest, err)
+		}
+
+		return nil
+	}
+
+	// A hard link fails if the destination exists, unlike a rename.
+	err := os.Link(af.f.Name(), af.dest)
+	if err == nil {
+		_ = os.Remove(af.f.Name())
+
+		return nil
+	}
+
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %s: %w", errFileExists, af.dest, fs.ErrExist)
+	}
+
+	// The file system does not support hard links: fall back to a non-atomic check.
+	if _, err = os.Lstat(af.dest); err == nil {
+		return fmt.Errorf("%w: %s: %w", errFileExists, af.dest, fs.ErrExist)
+	}
+
+	if err = os.Rename(af.f.Name(), af.dest); err != nil {
+		return fmt.Errorf("renaming %s: %w", af.dest, err)
+	}
+
+	return nil
+}
+
+// abort closes and removes the temporary file.
+func (af *atomicFile) abort() {
+	_ = af.f.Close()
+	_ = os.Remove(af.f.Name())
+}
@@ -0,0 +1,115 @@
+package gotenberg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestStoreOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+
+	dest := filepath.Join(t.TempDir(), "out", "foo.pdf")
+
+	var sum string
+	err = c.Store(context.Background(), req, dest, WithFileMode(0o600), WithSHA256(&sum))
+	require.NoError(t, err)
+
+	expected := sha256.Sum256([]byte("%PDF-1.7"))
+	assert.Equal(t, hex.EncodeToString(expected[:]), sum)
+
+	info, err := os.Stat(dest)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+	}
+
+	err = c.Store(context.Background(), req, dest, WithNoOverwrite())
+	require.ErrorIs(t, err, fs.ErrExist)
+
+	// Overwriting is allowed by default.
+	require.NoError(t, c.Store(context.Background(), req, dest))
+
+	entries, err := os.ReadDir(filepath.Dir(dest))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must not be left behind")
+}
+
+func TestDirSinkFailure(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "foo.pdf")
+	require.NoError(t, os.WriteFile(dest, []byte("previous"), 0o644))
+
+	res := &Result{body: io.NopCloser(&failingReader{err: errors.New("read failed")})}
+	err := res.SaveTo(dest)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(data), "a failed write must leave the destination untouched")
+
+	entries, err := os.ReadDir(filepath.Dir(dest))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, strings.HasSuffix(entry.Name(), ".tmp"))
+	}
+}
+
+func TestStoreTo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="foo.pdf"`)
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+
+	rec := httptest.NewRecorder()
+	n, err := c.StoreTo(context.Background(), req, rec, WithForwardedHeaders())
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	assert.Equal(t, "%PDF-1.7", rec.Body.String())
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="foo.pdf"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "8", rec.Header().Get("Content-Length"))
+
+	rec = httptest.NewRecorder()
+	_, err = c.StoreTo(context.Background(), req, rec)
+	require.NoError(t, err)
+	assert.Empty(t, rec.Header().Get("Content-Disposition"), "headers are only forwarded on demand")
+
+	req.UseWebhook("http://hook", "http://hook/error")
+	_, err = c.StoreTo(context.Background(), req, rec)
+	require.ErrorIs(t, err, errWebhookNotAllowed)
+}
@@ -0,0 +1,202 @@
+package gotenberg
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings is the breakdown of the duration of a request, measured with net/http/httptrace.
+// Durations of phases which did not happen, e.g., the connection of a reused connection, are zero.
+type Timings struct {
+	// Connect is the duration of the TCP connection.
+	Connect time.Duration
+	// TLS is the duration of the TLS handshake.
+	TLS time.Duration
+	// Upload is the duration from obtaining a connection until the request, documents included, is fully sent.
+	Upload time.Duration
+	// TTFB is the time to first byte, from the end of the upload until the first byte of the response:
+	// mostly the processing time of Gotenberg.
+	TTFB time.Duration
+	// Download is the duration from the first byte of the response until its body is read entirely or closed.
+	// It is zero until then.
+	Download time.Duration
+	// Total is the duration from the start of the request until the end of the download, or of the
+	// last measured phase.
+	Total time.Duration
+	// ConnReused reports whether the request was sent over a previously used connection.
+	ConnReused bool
+}
+
+// TimingsOf returns the timings of a response returned by Client.Send or Client.Screenshot.
+// The second result is false if the response was not sent by the client, e.g., it was made up by a middleware.
+func TimingsOf(resp *http.Response) (Timings, bool) {
+	t := timerOf(resp)
+	if t == nil {
+		return Timings{}, false
+	}
+
+	return t.timings(), true
+}
+
+// timerOf returns the timer of the request of the response, if any.
+func timerOf(resp *http.Response) *timer {
+	if resp == nil || resp.Request == nil {
+		return nil
+	}
+
+	t, _ := resp.Request.Context().Value(timerKey{}).(*timer)
+
+	return t
+}
+
+// Timings returns the timings of the call so far. Middlewares may read it once the next handler has returned,
+// in which case the download is not measured yet.
+func (c *Call) Timings() Timings {
+	return c.timer.timings()
+}
+
+// Timings returns the timings of the request which produced the result. The download is measured once
+// the result has been read or closed.
+func (r *Result) Timings() Timings {
+	return r.timer.timings()
+}
+
+type timerKey struct{}
+
+// timer records the instants of the phases of a request.
+type timer struct {
+	mu sync.Mutex
+
+	start, connectStart, connectDone, tlsStart, tlsDone time.Time
+	gotConn, wroteRequest, firstByte, bodyDone          time.Time
+	connReused                                          bool
+}
+
+func newTimer() *timer {
+	return &timer{start: time.Now()}
+}
+
+// withTimer returns a copy of the request tracing its phases with the timer.
+func (t *timer) withTimer(req *http.Request) *http.Request {
+	ctx := context.WithValue(req.Context(), timerKey{}, t)
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		ConnectStart: func(string, string) {
+			t.setOnce(&t.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			t.set(&t.connectDone)
+		},
+		TLSHandshakeStart: func() {
+			t.setOnce(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.set(&t.tlsDone)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.gotConn = time.Now()
+			t.connReused = info.Reused
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.set(&t.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			t.set(&t.firstByte)
+		},
+	})
+
+	return req.WithContext(ctx)
+}
+
+func (t *timer) set(instant *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	*instant = time.Now()
+}
+
+// setOnce keeps the first instant, e.g., when several addresses are dialed.
+func (t *timer) setOnce(instant *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if instant.IsZero() {
+		*instant = time.Now()
+	}
+}
+
+func (t *timer) timings() Timings {
+	if t == nil {
+		return Timings{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := Timings{
+		Connect:    between(t.connectStart, t.connectDone),
+		TLS:        between(t.tlsStart, t.tlsDone),
+		Upload:     between(t.gotConn, t.wroteRequest),
+		TTFB:       between(t.wroteRequest, t.firstByte),
+		Download:   between(t.firstByte, t.bodyDone),
+		ConnReused: t.connReused,
+	}
+
+	for _, end := range []time.Time{t.bodyDone, t.firstByte, t.wroteRequest, t.gotConn} {
+		if !end.IsZero() {
+			timings.Total = between(t.start, end)
+
+			break
+		}
+	}
+
+	return timings
+}
+
+func between(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+
+	return end.Sub(start)
+}
+
+// trackBody makes the response body record the end of the download.
+func (t *timer) trackBody(resp *http.Response) {
+	resp.Body = &timedBody{ReadCloser: resp.Body, timer: t}
+}
+
+type timedBody struct {
+	io.ReadCloser
+
+	timer *timer
+	once  sync.Once
+}
+
+func (tb *timedBody) Read(p []byte) (int, error) {
+	n, err := tb.ReadCloser.Read(p)
+	if err == io.EOF {
+		tb.done()
+	}
+
+	return n, err //nolint:wrapcheck // io.EOF must not be wrapped.
+}
+
+func (tb *timedBody) Close() error {
+	tb.done()
+
+	return tb.ReadCloser.Close() //nolint:wrapcheck // the body error is returned as is.
+}
+
+func (tb *timedBody) done() {
+	tb.once.Do(func() {
+		tb.timer.set(&tb.timer.bodyDone)
+	})
+}
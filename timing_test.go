@@ -0,0 +1,64 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestTimings(t *testing.T) {
+	const processing = 50 * time.Millisecond
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(processing)
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer srv.Close()
+
+	var callTimings Timings
+
+	c, err := NewClient(srv.URL, nil, WithMiddleware(func(next Handler) Handler {
+		return func(call *Call) (*http.Response, error) {
+			resp, err := next(call)
+			callTimings = call.Timings()
+
+			return resp, err
+		}
+	}))
+	require.NoError(t, err)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+
+	res, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, callTimings.TTFB, processing)
+	assert.Zero(t, callTimings.Download, "the download is not measured before the body is read")
+
+	_, err = res.Bytes()
+	require.NoError(t, err)
+
+	timings := res.Timings()
+	assert.GreaterOrEqual(t, timings.TTFB, processing)
+	assert.Positive(t, timings.Connect)
+	assert.False(t, timings.ConnReused)
+	assert.GreaterOrEqual(t, timings.Total, timings.Connect+timings.Upload+timings.TTFB+timings.Download)
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	timings, ok := TimingsOf(resp)
+	require.True(t, ok)
+	assert.True(t, timings.ConnReused)
+	assert.Zero(t, timings.Connect)
+}
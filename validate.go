@@ -0,0 +1,166 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+var errUnexpectedContentType = errors.New("unexpected document content type")
+
+// validator is implemented by requests which check their documents before being sent.
+type validator interface {
+	validate() error
+}
+
+// WithoutInputValidation disables the validation of the documents of requests before they are sent.
+// By default, the content of each document is sniffed, and a request fails without being sent if a document
+// is obviously not accepted by its route, e.g., a Word document passed to a MergeRequest.
+func WithoutInputValidation() ClientOption {
+	return func(c *Client) error {
+		c.skipValidation = true
+
+		return nil
+	}
+}
+
+// validate checks the documents of the request, unless validation is disabled.
+func (c *Client) validate(req Request) error {
+	v, ok := req.(validator)
+	if c.skipValidation || !ok {
+		return nil
+	}
+
+	return v.validate()
+}
+
+// contentRule tells which content types a route accepts for a document.
+type contentRule struct {
+	expected string
+	accepts  func(contentType string) bool
+}
+
+//nolint:gochecknoglobals // read-only rules.
+var (
+	rulePDF = contentRule{
+		expected: "a PDF",
+		accepts: func(contentType string) bool {
+			return contentType == document.ContentTypePDF
+		},
+	}
+	// Chromium renders any text, e.g., XHTML sniffed as text/xml, so only formats it cannot render are rejected.
+	ruleHTML = contentRule{
+		expected: "an HTML file",
+		accepts:  isText,
+	}
+	ruleMarkdown = contentRule{
+		expected: "a Markdown file",
+		accepts:  isText,
+	}
+	ruleLibreOffice = contentRule{
+		expected: "a file supported by LibreOffice",
+		accepts: func(contentType string) bool {
+			return contentType != document.ContentTypePDF
+		},
+	}
+)
+
+// isText reports whether the content type may be the text file of a Chromium route, i.e., is not a PDF,
+// an office document or an image.
+func isText(contentType string) bool {
+	return contentType != document.ContentTypePDF && !document.IsOffice(contentType) && !document.IsImage(contentType)
+}
+
+// validateDocuments checks that the documents match the rule. Documents whose content type is unknown are accepted.
+func validateDocuments(rule contentRule, docs ...document.Document) error {
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		// A document which cannot be read is reported when the request is sent.
+		contentType, err := document.ContentTypeOf(doc)
+		if err == nil && contentType != "" && !rule.accepts(contentType) {
+			return fmt.Errorf("%w: %s is %s, expected %s", errUnexpectedContentType, doc.Filename(), contentType, rule.expected)
+		}
+	}
+
+	return nil
+}
+
+func (req *chromiumRequest) validate() error {
+	return validateDocuments(ruleHTML, req.header, req.footer)
+}
+
+func (req *HTMLRequest) validate() error {
+	if err := validateDocuments(ruleHTML, req.index); err != nil {
+		return err
+	}
+
+	return req.chromiumRequest.validate()
+}
+
+func (req *MarkdownRequest) validate() error {
+	if err := validateDocuments(ruleHTML, req.index); err != nil {
+		return err
+	}
+
+	if err := validateDocuments(ruleMarkdown, req.markdowns...); err != nil {
+		return err
+	}
+
+	return req.chromiumRequest.validate()
+}
+
+func (req *LibreOfficeRequest) validate() error {
+	return validateDocuments(ruleLibreOffice, req.docs...)
+}
+
+func (req *MergeRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (req *SplitPagesRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (req *SplitIntervalsRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (req *FlattenRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (req *EncryptRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (req *EmbedRequest) validate() error {
+	return validateDocuments(rulePDF, req.pdfs...)
+}
+
+func (rmd *ReadMetadataRequest) validate() error {
+	return validateDocuments(rulePDF, rmd.pdfs...)
+}
+
+func (wmd *WriteMetadataRequest) validate() error {
+	return validateDocuments(rulePDF, wmd.pdfs...)
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = validator(new(HTMLRequest))
+	_ = validator(new(URLRequest))
+	_ = validator(new(MarkdownRequest))
+	_ = validator(new(LibreOfficeRequest))
+	_ = validator(new(MergeRequest))
+	_ = validator(new(SplitPagesRequest))
+	_ = validator(new(SplitIntervalsRequest))
+	_ = validator(new(FlattenRequest))
+	_ = validator(new(EncryptRequest))
+	_ = validator(new(EmbedRequest))
+	_ = validator(new(ReadMetadataRequest))
+	_ = validator(new(WriteMetadataRequest))
+)
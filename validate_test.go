@@ -0,0 +1,82 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestInputValidation(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte("%PDF-"))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil)
+	require.NoError(t, err)
+
+	pdf, err := document.FromPath("gotenberg.pdf", "test/data/pdf/gotenberg.pdf")
+	require.NoError(t, err)
+	docx, err := document.FromPath("document.docx", "test/data/libreoffice/document.docx")
+	require.NoError(t, err)
+	index, err := document.FromPath("index.html", "test/data/html/index.html")
+	require.NoError(t, err)
+	xhtml, err := document.FromString("index.html", `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"><body>Foo</body></html>`)
+	require.NoError(t, err)
+	img, err := document.FromPath("img.gif", "test/data/html/img.gif")
+	require.NoError(t, err)
+	markdown, err := document.FromString("paragraph.md", "# Foo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     MultipartRequest
+		invalid string
+	}{
+		{name: "merge with a Word document", req: NewMergeRequest(pdf, docx), invalid: "document.docx"},
+		{name: "LibreOffice with a PDF", req: NewLibreOfficeRequest(docx, pdf), invalid: "gotenberg.pdf"},
+		{name: "HTML with a PDF index", req: NewHTMLRequest(pdf), invalid: "gotenberg.pdf"},
+		{name: "HTML with an image index", req: NewHTMLRequest(img), invalid: "img.gif"},
+		{name: "Markdown with a Word document", req: NewMarkdownRequest(index, docx), invalid: "document.docx"},
+		{name: "valid merge", req: NewMergeRequest(pdf)},
+		{name: "valid LibreOffice", req: NewLibreOfficeRequest(docx)},
+		{name: "valid HTML", req: NewHTMLRequest(index)},
+		{name: "valid XHTML", req: NewHTMLRequest(xhtml)},
+		{name: "valid Markdown", req: NewMarkdownRequest(index, markdown)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls.Store(0)
+
+			_, err := c.StoreTo(context.Background(), tc.req, &countingWriter{})
+			if tc.invalid == "" {
+				require.NoError(t, err)
+				assert.Equal(t, int32(1), calls.Load())
+
+				return
+			}
+
+			require.ErrorIs(t, err, errUnexpectedContentType)
+			assert.Contains(t, err.Error(), tc.invalid)
+			assert.Zero(t, calls.Load(), "an invalid request must not be sent")
+		})
+	}
+
+	c, err = NewClient(srv.URL, nil, WithoutInputValidation())
+	require.NoError(t, err)
+
+	_, err = c.StoreTo(context.Background(), NewMergeRequest(docx), &countingWriter{})
+	require.NoError(t, err)
+}
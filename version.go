@@ -0,0 +1,208 @@
+package gotenberg
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const endpointVersion = "/version"
+
+var (
+	errInvalidVersion      = errors.New("invalid version")
+	errUnsupportedByServer = errors.New("not supported by the Gotenberg server")
+)
+
+// Version is a Gotenberg release version.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a version such as "8.11.0" or "v8.11.0". Pre-release and build suffixes are ignored.
+func ParseVersion(s string) (Version, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(trimmed, "-+ "); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("%w: %q", errInvalidVersion, s)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("%w: %q", errInvalidVersion, s)
+		}
+
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower than, equal to or greater than other.
+func (v Version) Compare(other Version) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(v.Patch, other.Patch)
+}
+
+// AtLeast reports whether v is greater than or equal to other.
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// Minimum Gotenberg versions of the routes which were added after 8.0.0.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var endpointMinVersions = map[string]Version{
+	endpointHTMLScreenshot:      {8, 3, 0},
+	endpointURLScreenshot:       {8, 3, 0},
+	endpointMarkdownScreenshot:  {8, 3, 0},
+	"/forms/pdfengines/split":   {8, 9, 0},
+	"/forms/pdfengines/flatten": {8, 16, 0},
+	"/forms/pdfengines/encrypt": {8, 17, 0},
+	"/forms/pdfengines/embed":   {8, 20, 0},
+}
+
+// Minimum Gotenberg versions of the form fields which were added after 8.0.0.
+// An older server silently ignores them.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var fieldMinVersions = map[formField]Version{
+	fieldChromiumCookies:                       {8, 5, 0},
+	fieldChromiumFailOnResourceHTTPStatusCodes: {8, 6, 0},
+	fieldChromiumFailOnResourceLoadingFailed:   {8, 6, 0},
+	fieldChromiumSkipNetworkIdleEvent:          {8, 1, 0},
+	fieldChromiumGenerateDocumentOutline:       {8, 14, 0},
+	fieldChromiumGenerateTaggedPDF:             {8, 15, 0},
+	fieldDownloadFrom:                          {8, 10, 0},
+	fieldSplitMode:                             {8, 9, 0},
+	fieldSplitSpan:                             {8, 9, 0},
+	fieldSplitUnify:                            {8, 9, 0},
+	fieldOfficeFlatten:                         {8, 16, 0},
+	fieldOfficeUpdateIndexes:                   {8, 13, 0},
+	fieldUserPassword:                          {8, 17, 0},
+	fieldOwnerPassword:                         {8, 17, 0},
+}
+
+// Minimum Gotenberg version supporting the embeds form files.
+//
+//nolint:gochecknoglobals // read-only value.
+var embedsMinVersion = Version{8, 20, 0}
+
+// Version returns the version of the Gotenberg instance, as returned by its /version endpoint.
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	req, err := c.createGetRequest(ctx, endpointVersion)
+	if err != nil {
+		return Version{}, err
+	}
+
+	resp, err := c.doHTTP(req)
+	if err != nil {
+		return Version{}, fmt.Errorf("%w: %w", errSendRequestFailed, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return Version{}, newGotenbergError(resp, nil, endpointVersion)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return Version{}, fmt.Errorf("reading version: %w", err)
+	}
+
+	return ParseVersion(string(data))
+}
+
+// WithStrictVersionCheck makes the client check, before sending a request, that the Gotenberg instance is recent
+// enough to support the route and all the form fields set on the request. Otherwise, the request fails without
+// being sent, instead of an older server silently ignoring unknown fields.
+//
+// The server version is fetched once, on the first request, and cached.
+func WithStrictVersionCheck() ClientOption {
+	return func(c *Client) error {
+		c.versionCheck = &versionCheck{}
+
+		return nil
+	}
+}
+
+type versionCheck struct {
+	mu      sync.Mutex
+	version *Version
+}
+
+// serverVersion returns the cached server version, fetching it if needed. Failed fetches are not cached.
+func (vc *versionCheck) serverVersion(ctx context.Context, c *Client) (Version, error) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if vc.version != nil {
+		return *vc.version, nil
+	}
+
+	v, err := c.Version(ctx)
+	if err != nil {
+		return Version{}, fmt.Errorf("getting Gotenberg version: %w", err)
+	}
+
+	vc.version = &v
+
+	return v, nil
+}
+
+// checkVersion ensures the server supports the request, if the strict version check is enabled.
+func (c *Client) checkVersion(ctx context.Context, mr MultipartRequest, endpoint string) error {
+	if c.versionCheck == nil {
+		return nil
+	}
+
+	server, err := c.versionCheck.serverVersion(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if minVersion, ok := endpointMinVersions[endpoint]; ok && !server.AtLeast(minVersion) {
+		return fmt.Errorf("%w: route %s requires Gotenberg %s, server is %s",
+			errUnsupportedByServer, endpoint, minVersion, server)
+	}
+
+	for field := range mr.formFields() {
+		if minVersion, ok := fieldMinVersions[field]; ok && !server.AtLeast(minVersion) {
+			return fmt.Errorf("%w: form field %s requires Gotenberg %s, server is %s",
+				errUnsupportedByServer, field, minVersion, server)
+		}
+	}
+
+	if len(mr.formEmbeds()) > 0 && !server.AtLeast(embedsMinVersion) {
+		return fmt.Errorf("%w: embeds require Gotenberg %s, server is %s",
+			errUnsupportedByServer, embedsMinVersion, server)
+	}
+
+	return nil
+}
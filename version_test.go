@@ -0,0 +1,87 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starwalkn/gotenberg-go-client/v8/document"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+	}{
+		{input: "8.25.0", expected: Version{8, 25, 0}},
+		{input: "v8.11.2\n", expected: Version{8, 11, 2}},
+		{input: "8.12.0-rc1", expected: Version{8, 12, 0}},
+		{input: "8.4", expected: Version{8, 4, 0}},
+	}
+
+	for _, tc := range tests {
+		v, err := ParseVersion(tc.input)
+		require.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, v)
+	}
+
+	for _, input := range []string{"", "8", "eight.1.0", "8.1.0.1", "8.-1.0"} {
+		_, err := ParseVersion(input)
+		require.ErrorIs(t, err, errInvalidVersion, input)
+	}
+
+	assert.True(t, Version{8, 11, 0}.AtLeast(Version{8, 3, 0}))
+	assert.False(t, Version{8, 2, 9}.AtLeast(Version{8, 3, 0}))
+	assert.Equal(t, "8.11.0", Version{8, 11, 0}.String())
+}
+
+func TestStrictVersionCheck(t *testing.T) {
+	var versionCalls, conversions atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == endpointVersion {
+			versionCalls.Add(1)
+			_, _ = w.Write([]byte("8.10.0"))
+
+			return
+		}
+
+		conversions.Add(1)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, nil, WithStrictVersionCheck())
+	require.NoError(t, err)
+
+	// Version does not fill the cache of the version check.
+	v, err := c.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Version{8, 10, 0}, v)
+
+	index, err := document.FromString("index.html", "<html>Foo</html>")
+	require.NoError(t, err)
+	req := NewHTMLRequest(index)
+	req.SkipNetworkIdleEvent(true)
+
+	resp, err := c.Send(context.Background(), req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	req.GenerateTaggedPDF(true)
+	_, err = c.Send(context.Background(), req)
+	require.ErrorIs(t, err, errUnsupportedByServer)
+	assert.Contains(t, err.Error(), "generateTaggedPdf")
+
+	pdf, err := document.FromString("gotenberg.pdf", "%PDF-")
+	require.NoError(t, err)
+	_, err = c.Send(context.Background(), NewFlattenRequest(pdf))
+	require.ErrorIs(t, err, errUnsupportedByServer)
+
+	assert.Equal(t, int32(2), versionCalls.Load())
+	assert.Equal(t, int32(1), conversions.Load())
+}